@@ -0,0 +1,26 @@
+// Package access provides several ways of describing how Other attempted to interface with a std.Thought.
+//
+// See Kind, Reveal, Describe, and Watch
+package access
+
+// Kind defines the manner in which Other attempted to access a std.Thought
+//
+// See Kind, Reveal, Describe, and Watch
+type Kind byte
+
+const (
+	// Reveal indicates that Other attempted to read from the std.Thought
+	//
+	// See Kind, Reveal, Describe, and Watch
+	Reveal Kind = iota
+
+	// Describe indicates that Other attempted to write to the std.Thought
+	//
+	// See Kind, Reveal, Describe, and Watch
+	Describe
+
+	// Watch indicates that Other attempted to observe changes to the std.Thought
+	//
+	// See Kind, Reveal, Describe, and Watch
+	Watch
+)
@@ -0,0 +1,26 @@
+// Package backpressure provides several ways of describing how a publisher should treat a subscriber who can't keep up.
+//
+// See Policy, DropOldest, Block, and Coalesce
+package backpressure
+
+// Policy defines how a publisher behaves when a subscriber has not yet received its prior deliveries.
+//
+// See Policy, DropOldest, Block, and Coalesce
+type Policy byte
+
+const (
+	// DropOldest indicates that the oldest undelivered element should be discarded to make room for the newest
+	//
+	// See Policy, DropOldest, Block, and Coalesce
+	DropOldest Policy = iota
+
+	// Block indicates that the publisher should wait until the subscriber receives the element or cancels
+	//
+	// See Policy, DropOldest, Block, and Coalesce
+	Block
+
+	// Coalesce indicates that only the single newest undelivered element should be held for the subscriber
+	//
+	// See Policy, DropOldest, Block, and Coalesce
+	Coalesce
+)
@@ -0,0 +1,26 @@
+// Package boundary provides several ways of describing how a cursor should treat movement beyond the edges of its data.
+//
+// See Mode, Panic, Clamp, and Flow
+package boundary
+
+// Mode defines how a cursor behaves when a movement would carry it outside its data's boundaries.
+//
+// See Mode, Panic, Clamp, and Flow
+type Mode byte
+
+const (
+	// Panic indicates that moving outside the data's boundaries should panic, just like traditional index access
+	//
+	// See Mode, Panic, Clamp, and Flow
+	Panic Mode = iota
+
+	// Clamp indicates that movement should gracefully stop at the nearest boundary
+	//
+	// See Mode, Panic, Clamp, and Flow
+	Clamp
+
+	// Flow indicates that movement should over or underflow to the other side of the data
+	//
+	// See Mode, Panic, Clamp, and Flow
+	Flow
+)
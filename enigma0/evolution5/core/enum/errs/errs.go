@@ -4,3 +4,6 @@ import "errors"
 
 var InvalidCode = errors.New("the provided code was invalid")
 var InvalidPath = errors.New("the provided path was invalid")
+var Irreversible = errors.New("the revelation has no means of returning to its idealized form")
+var NotTransacted = errors.New("the thought is not a participant of the transaction")
+var Forgotten = errors.New("the moment precedes what the thought remembers")
@@ -0,0 +1,31 @@
+// Package interpolation provides several ways of describing how to estimate a signal's value between its known samples.
+//
+// See Mode, Nearest, Linear, Hold, and Cubic
+package interpolation
+
+// Mode defines how a value is derived between two known samples
+//
+// See Mode, Nearest, Linear, Hold, and Cubic
+type Mode byte
+
+const (
+	// Nearest indicates the value of whichever sample is temporally closest should be used
+	//
+	// See Mode, Nearest, Linear, Hold, and Cubic
+	Nearest Mode = iota
+
+	// Linear indicates the value should be drawn from a straight line between the surrounding samples
+	//
+	// See Mode, Nearest, Linear, Hold, and Cubic
+	Linear
+
+	// Hold indicates the value of the latest prior sample should be held until the next (a "zero-order hold")
+	//
+	// See Mode, Nearest, Linear, Hold, and Cubic
+	Hold
+
+	// Cubic indicates the value should be drawn from a smooth cubic curve passing through the surrounding samples
+	//
+	// See Mode, Nearest, Linear, Hold, and Cubic
+	Cubic
+)
@@ -26,6 +26,12 @@ var framebuffers = make(map[uint32]*image.RGBA)
 var mutex = &sync.Mutex{}
 var frameNumber = uint(0)
 
+// These statistics record every tick of the orchestration clock and every presentation to a window - see std.Performance.
+var (
+	frames        = std.Performance("Glitter", "Frames")
+	presentations = std.Performance("Glitter", "Present")
+)
+
 // Orchestrate begins the SDL2 system and facilitates the neural rendering of graphical contexts.
 func Orchestrate() {
 	rec.Verbosef(ModuleName, "initializing SDL2\n")
@@ -46,12 +52,14 @@ func Orchestrate() {
 				last = n
 				next = last.Add(when.HertzToDuration(FrameRate))
 
+				frames.Hit(delta)
 				Synchro.Send(func() {
 					mutex.Lock()
 					for id, viewport := range windows {
 						fb := framebuffers[id]
 						presented := false
 						presentLock := &sync.Mutex{}
+						dispatched := time.Now()
 						select {
 						case viewport.impulse <- Frame{
 							Image:  fb,
@@ -66,6 +74,7 @@ func Orchestrate() {
 								if !presented {
 									presented = true
 									viewport.present(fb)
+									presentations.Hit(time.Since(dispatched))
 								}
 							},
 						}:
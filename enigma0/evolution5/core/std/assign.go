@@ -0,0 +1,357 @@
+package std
+
+import (
+	"fmt"
+	"reflect"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/access"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+// A describer is anything which can be described with a code - such as a Thought or Epiphany.
+type describer interface {
+	describeAny(revelation any, code ...any) error
+
+	// updateAny reveals, modifies, and describes as a single step - see Thought.update.
+	updateAny(fn func(revealed any) (any, error), code ...any) error
+}
+
+// mayDescribe returns the error describing with the provided code would yield, without describing anything.
+func (t *Thought[T]) mayDescribe(code ...any) error {
+	t.sanityCheck()
+	if t.disclosure.Constraint == relationally.Open || t.disclosure.Check(code...) {
+		return nil
+	}
+	t.disclosure.audit(access.Describe, false, nil, code...)
+	return errs.InvalidCode
+}
+
+func (e *Epiphany[TIdealized, TMaterialized]) mayDescribe(code ...any) error {
+	if e.idealize == nil {
+		return errs.Irreversible
+	}
+	return e.thought.mayDescribe(code...)
+}
+
+func (t *Thought[T]) describeAny(revelation any, code ...any) error {
+	v, ok := convertStep(revelation, reflect.TypeFor[T]())
+	if !ok {
+		return fmt.Errorf("a %T can't describe a std.Thought[%v]", revelation, reflect.TypeFor[T]())
+	}
+	return t.Describe(v.Interface().(T), code...)
+}
+
+func (e *Epiphany[TIdealized, TMaterialized]) describeAny(revelation any, code ...any) error {
+	v, ok := convertStep(revelation, reflect.TypeFor[TMaterialized]())
+	if !ok {
+		return fmt.Errorf("a %T can't revise a std.Epiphany's %v revelation", revelation, reflect.TypeFor[TMaterialized]())
+	}
+	return e.Revise(v.Interface().(TMaterialized), code...)
+}
+
+func (t *Thought[T]) updateAny(fn func(revealed any) (any, error), code ...any) error {
+	return t.update(func(revelation *T) error {
+		updated, err := fn(*revelation)
+		if err != nil {
+			return err
+		}
+		v, ok := convertStep(updated, reflect.TypeFor[T]())
+		if !ok {
+			return fmt.Errorf("a %T can't describe a std.Thought[%v]", updated, reflect.TypeFor[T]())
+		}
+		*revelation = v.Interface().(T)
+		return nil
+	}, code...)
+}
+
+func (e *Epiphany[TIdealized, TMaterialized]) updateAny(fn func(revealed any) (any, error), code ...any) error {
+	return e.update(func(revelation *TMaterialized) error {
+		updated, err := fn(*revelation)
+		if err != nil {
+			return err
+		}
+		v, ok := convertStep(updated, reflect.TypeFor[TMaterialized]())
+		if !ok {
+			return fmt.Errorf("a %T can't revise a std.Epiphany's %v revelation", updated, reflect.TypeFor[TMaterialized]())
+		}
+		*revelation = v.Interface().(TMaterialized)
+		return nil
+	}, code...)
+}
+
+// Assign walks the provided Path relative to the source using the same rules as Locate, then sets the value at its
+// final step - be it a struct field, map key, or slice element.  If the final step addresses a Thought (or Epiphany),
+// it's described with the final step's code rather than replaced.  Any Thought walked through along the way is
+// revealed with its step's code, assigned into, and then described again with the same code - all while holding the
+// thought's gate, so concurrent assignments into the same thought never lose one another's changes.
+//
+// NOTE: A revealed Thought may share its maps, slices, and pointers with the thought itself - so the code is checked
+// before walking into it, and every container along the path is copied before being assigned into.  The thought
+// only ever changes through its final Describe.  As each thought's gate is held while walking within it, a thought
+// must never (even indirectly) contain itself.
+//
+// If 'create' is true, missing map keys and nil maps, slices, and pointers are created on demand - slices are grown
+// to fit the addressed index, and an intermediate of an interface type is created as a map[string]any.
+//
+// NOTE: The source itself can't be replaced, so it should be something assignable through - such as a pointer,
+// map, or Thought.  Rather than panicking, any step which can't be walked yields a *PathError satisfying errs.InvalidPath.
+func Assign(source any, relative Path, value any, create ...bool) error {
+	if len(relative) == 0 {
+		return &PathError{Index: 0, Reason: "an empty path has nothing to assign"}
+	}
+	a := assignment{
+		path:   relative,
+		value:  value,
+		create: len(create) > 0 && create[0],
+	}
+	_, err := a.walk(reflect.ValueOf(source), 0)
+	return err
+}
+
+// An assignment carries the state of a single call to Assign as it recursively walks the path.
+type assignment struct {
+	path   Path
+	value  any
+	create bool
+
+	// isolate indicates the walk is within a revealed Thought, so containers must be copied before assigning into them
+	isolate bool
+}
+
+func (a assignment) fail(i int, reason string, cause ...error) error {
+	err := &PathError{Index: i, Step: a.path[i], Reason: reason}
+	if len(cause) > 0 {
+		err.Cause = cause[0]
+	}
+	return err
+}
+
+// walk assigns into the provided target at step 'i' and returns the target as it should be written back by its parent.
+//
+// NOTE: Containers which aren't addressable (such as structs held in a map) are copied, assigned into, and returned.
+func (a assignment) walk(target reflect.Value, i int) (reflect.Value, error) {
+	data, code := stepOf(a.path[i])
+	last := i == len(a.path)-1
+
+	for target.IsValid() && target.Kind() == reflect.Interface && !target.IsNil() {
+		target = target.Elem()
+	}
+	if !target.IsValid() {
+		return target, a.fail(i, "there is nothing to assign into")
+	}
+
+	// Thoughts are revealed, assigned into, and then described with the same code
+	if target.CanInterface() && target.Kind() == reflect.Pointer && !target.IsNil() {
+		if _, ok := target.Interface().(revealer); ok {
+			d, describable := target.Interface().(describer)
+			if !describable {
+				return target, a.fail(i, "the thought can't be described")
+			}
+			isolated := a
+			isolated.isolate = true
+			var walkErr error
+			err := d.updateAny(func(revealed any) (any, error) {
+				updated, err := isolated.walk(reflect.ValueOf(revealed), i)
+				if err != nil {
+					walkErr = err
+					return nil, err
+				}
+				return updated.Interface(), nil
+			}, codesOf(code)...)
+			if walkErr != nil {
+				return target, walkErr
+			}
+			if err != nil {
+				return target, a.fail(i, "the thought could not be updated", err)
+			}
+			return target, nil
+		}
+	}
+
+	switch target.Kind() {
+	case reflect.Pointer:
+		if target.IsNil() {
+			if !a.create {
+				return target, a.fail(i, "the pointer is nil")
+			}
+			target = reflect.New(target.Type().Elem())
+		} else if a.isolate {
+			target = copyOf(target)
+		}
+		updated, err := a.walk(target.Elem(), i)
+		if err != nil {
+			return target, err
+		}
+		target.Elem().Set(updated)
+		return target, nil
+	case reflect.Struct:
+		name, ok := stringOf(data)
+		if !ok {
+			return target, a.fail(i, "a field name must be Stringable")
+		}
+		field, found := target.Type().FieldByName(name)
+		if !found || !field.IsExported() {
+			return target, a.fail(i, fmt.Sprintf("%v has no exported field named '%s'", target.Type(), name))
+		}
+		target = addressable(target)
+		slot, ok := a.field(target, field.Index)
+		if !ok {
+			return target, a.fail(i, fmt.Sprintf("the field '%s' is promoted through a nil embedded pointer", name))
+		}
+		return target, a.set(slot, i, last, code)
+	case reflect.Map:
+		if target.IsNil() {
+			if !a.create {
+				return target, a.fail(i, "the map is nil")
+			}
+			target = reflect.MakeMap(target.Type())
+		} else if a.isolate {
+			target = copyOf(target)
+		}
+		key, ok := convertStep(data, target.Type().Key())
+		if !ok {
+			return target, a.fail(i, fmt.Sprintf("the step is not a valid %v key", target.Type().Key()))
+		}
+		slot := reflect.New(target.Type().Elem()).Elem()
+		if existing := target.MapIndex(key); existing.IsValid() {
+			slot.Set(existing)
+		} else if !last && !a.create {
+			return target, a.fail(i, "the map has no such key")
+		}
+		if err := a.set(slot, i, last, code); err != nil {
+			return target, err
+		}
+		target.SetMapIndex(key, slot)
+		return target, nil
+	case reflect.Slice, reflect.Array:
+		index, ok := indexOf(data)
+		if !ok {
+			return target, a.fail(i, "the step is not a valid index")
+		}
+		if a.isolate && target.Kind() == reflect.Slice {
+			target = copyOf(target)
+		}
+		if index >= target.Len() {
+			if target.Kind() == reflect.Array || !a.create {
+				return target, a.fail(i, fmt.Sprintf("the index is out of range [0:%d]", target.Len()))
+			}
+			target = reflect.AppendSlice(target, reflect.MakeSlice(target.Type(), index+1-target.Len(), index+1-target.Len()))
+		}
+		if target.Kind() == reflect.Array {
+			target = addressable(target)
+		}
+		return target, a.set(target.Index(index), i, last, code)
+	default:
+		return target, a.fail(i, fmt.Sprintf("a %v can't be assigned into", target.Type()))
+	}
+}
+
+// set assigns the value into the provided addressable slot if this is the final step - otherwise, it walks into the
+// slot with the next step and writes the result back.
+func (a assignment) set(slot reflect.Value, i int, last bool, code any) error {
+	if last {
+		if slot.CanInterface() && !isNil(slot) {
+			if d, ok := slot.Interface().(describer); ok {
+				if err := d.describeAny(a.value, codesOf(code)...); err != nil {
+					return a.fail(i, "the thought could not be described", err)
+				}
+				return nil
+			}
+		}
+		v, ok := convertStep(a.value, slot.Type())
+		if !ok {
+			return a.fail(i, fmt.Sprintf("a %T can't be assigned to a %v", a.value, slot.Type()))
+		}
+		slot.Set(v)
+		return nil
+	}
+
+	inner := slot
+	if isNil(slot) {
+		if !a.create {
+			return a.fail(i+1, "there is nothing to assign into")
+		}
+		if slot.Kind() == reflect.Interface {
+			inner = reflect.ValueOf(make(map[string]any))
+		}
+	}
+	updated, err := a.walk(inner, i+1)
+	if err != nil {
+		return err
+	}
+	slot.Set(updated)
+	return nil
+}
+
+// field walks the addressable struct to the field at the provided index sequence.  Embedded pointers along the way
+// are created if nil (and 'create' is set), or copied if isolated - otherwise, a nil embedded pointer yields false.
+func (a assignment) field(v reflect.Value, index []int) (reflect.Value, bool) {
+	for depth, i := range index {
+		if depth > 0 && v.Kind() == reflect.Pointer {
+			switch {
+			case !v.IsNil() && !a.isolate:
+			case !v.CanSet() || (v.IsNil() && !a.create):
+				return reflect.Value{}, false
+			case v.IsNil():
+				v.Set(reflect.New(v.Type().Elem()))
+			default:
+				v.Set(copyOf(v))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
+
+// addressable returns the provided value if it can be set, otherwise an addressable copy of it.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanSet() {
+		return v
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+// copyOf returns a shallow copy of the provided pointer's target, map, or slice - leaving the original untouched.
+func copyOf(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(v.Elem())
+		return c
+	case reflect.Map:
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), iter.Value())
+		}
+		return c
+	case reflect.Slice:
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(c, v)
+		return c
+	default:
+		return v
+	}
+}
+
+// isNil tests if the provided value is a nil pointer, map, slice, interface, channel, or function.
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface, reflect.Chan, reflect.Func:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// codesOf wraps a step's code for revealing or describing, omitting it entirely if nil.
+func codesOf(code any) []any {
+	if code == nil {
+		return nil
+	}
+	return []any{code}
+}
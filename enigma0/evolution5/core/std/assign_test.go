@@ -0,0 +1,148 @@
+package std
+
+import (
+	"errors"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+type assigned struct {
+	Name  string
+	Items []int
+	Inner *assigned
+	*Embedded
+}
+
+func TestAssign(t *testing.T) {
+	memory, _ := NewThought(map[string]any{})
+	if err := Assign(memory, Path{"a", "b", "c"}, 5); err == nil {
+		t.Fatal("expected missing keys to fail without 'create'")
+	}
+	if err := Assign(memory, Path{"a", "b", "c"}, 5, true); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := Locate(memory, Path{"a", "b", "c"}); v != 5 {
+		t.Fatalf("Locate = %v, %v - want 5", v, err)
+	}
+
+	s := &assigned{}
+	if err := Assign(s, Path{"Items", 2}, "7", true); err != nil || len(s.Items) != 3 || s.Items[2] != 7 {
+		t.Fatalf("Items = %v, %v - want [0 0 7]", s.Items, err)
+	}
+	if err := Assign(s, Path{"Inner", "Name"}, "x", true); err != nil || s.Inner.Name != "x" {
+		t.Fatalf("Inner = %v, %v - want a created pointer named x", s.Inner, err)
+	}
+
+	m := map[string]assigned{"k": {}}
+	if err := Assign(m, Path{"k", "Name"}, "y"); err != nil || m["k"].Name != "y" {
+		t.Fatalf("m = %v, %v - want k named y", m, err)
+	}
+}
+
+func TestAssignNilEmbeddedPointer(t *testing.T) {
+	s := &assigned{}
+	err := Assign(s, Path{"V"}, 3)
+	var pathErr *PathError
+	if !errors.As(err, &pathErr) || !errors.Is(err, errs.InvalidPath) {
+		t.Fatalf("Assign error = %v, want a *PathError", err)
+	}
+	if err = Assign(s, Path{"V"}, 3, true); err != nil || s.Embedded == nil || s.V != 3 {
+		t.Fatalf("Assign = %v, want the embedded pointer created and V set to 3", err)
+	}
+}
+
+func TestAssignThoughtConstraints(t *testing.T) {
+	d := &Disclosure{Constraint: relationally.Inclusive}
+	d.Code("secret")
+	thought, _ := NewThought(map[string]any{"a": 1, "nested": map[string]any{"b": 1}}, d)
+	before, _ := thought.Reveal()
+
+	for _, path := range []Path{{"a"}, {"nested", "b"}, {Step{Data: "a", Code: "wrong"}}} {
+		if err := Assign(thought, path, 2); !errors.Is(err, errs.InvalidCode) {
+			t.Fatalf("Assign(%v) error = %v, want %v", path, err, errs.InvalidCode)
+		}
+	}
+	if revealed, _ := thought.Reveal(); revealed["a"] != 1 || revealed["nested"].(map[string]any)["b"] != 1 {
+		t.Fatalf("revelation = %v after rejected assignments, want it unchanged", revealed)
+	}
+
+	if err := Assign(thought, Path{Step{Data: "nested", Code: "secret"}, "b"}, 2); err != nil {
+		t.Fatal(err)
+	}
+	if revealed, _ := thought.Reveal(); revealed["nested"].(map[string]any)["b"] != 2 {
+		t.Fatalf("revelation = %v, want nested b of 2", revealed)
+	}
+	if before["nested"].(map[string]any)["b"] != 1 {
+		t.Fatalf("a prior revelation = %v, want it untouched by the assignment", before)
+	}
+
+	inner, _ := NewThought(0, d)
+	holder := map[string]any{"t": inner}
+	if err := Assign(holder, Path{"t"}, 3); !errors.Is(err, errs.InvalidCode) {
+		t.Fatalf("Assign error = %v, want %v", err, errs.InvalidCode)
+	}
+	if err := Assign(holder, Path{Step{Data: "t", Code: "secret"}}, 3); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := inner.Reveal(); v != 3 || holder["t"] != inner {
+		t.Fatalf("inner thought = %v, want it described as 3 rather than replaced", v)
+	}
+}
+
+func TestAssignThoughtConcurrently(t *testing.T) {
+	thought, _ := NewThought(map[string]any{"counts": map[string]any{}})
+
+	// Every goroutine waits upon the same signal, so their assignments overlap as much as possible
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := range 64 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			<-start
+			if err := Assign(thought, Path{"counts", i}, i, true); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			<-start
+			_, _ = Locate(thought, Path{"counts"})
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	// Every assignment reads, modifies, and describes the thought as one step - so none may be lost to another
+	revealed, _ := thought.Reveal()
+	counts := revealed["counts"].(map[string]any)
+	for i := range 64 {
+		if counts[Stringify(i)] != i {
+			t.Errorf("counts[%d] = %v, want %d", i, counts[Stringify(i)], i)
+		}
+	}
+}
+
+func TestAssignEpiphany(t *testing.T) {
+	clone := func(m map[string]int) (map[string]int, error) { return maps.Clone(m), nil }
+	epi := NewEpiphany(clone, time.Hour, clone)
+	epi.Describe(map[string]int{"a": 1})
+
+	if err := Assign(epi, Path{"b"}, 2, true); err != nil {
+		t.Fatal(err)
+	}
+	if revealed, _ := epi.Reveal(); revealed["a"] != 1 || revealed["b"] != 2 || !epi.dirty {
+		t.Fatalf("revealed %v (dirty: %v), want a dirty revision holding a and b", revealed, epi.dirty)
+	}
+	if err := epi.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if ideal, _ := epi.thought.Reveal(); ideal["b"] != 2 {
+		t.Fatalf("committed %v, want b of 2", ideal)
+	}
+}
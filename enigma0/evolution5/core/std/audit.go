@@ -0,0 +1,113 @@
+package std
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/access"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+// An Access is a single attempt to interface with a relationally.Inclusive or relationally.Exclusive Thought, as
+// recorded into its Disclosure's Audit.
+type Access struct {
+	// Kind defines whether Other attempted to reveal or describe the thought.
+	Kind access.Kind
+
+	// Granted indicates whether the attempt passed the disclosure's constraint.
+	Granted bool
+
+	// Bridge holds the calling impulse's Bridge, or nil if the caller didn't identify itself through RevealAs,
+	// DescribeAs, or WatchAs.
+	Bridge Path
+
+	// CodeHash holds the hex encoded HMAC-SHA-256 of the code used, or "" if no code was provided.
+	//
+	// NOTE: The HMAC is keyed randomly whenever the process starts, so hashes can be compared against each other
+	// within a process - but a leaked trail can't be brute forced back into its codes, or correlated with another
+	// process's.  Stringable codes are hashed by their string form, while Negotiable codes are hashed by their type.
+	CodeHash string
+}
+
+// audit records an access attempt into the Audit buffer, if one is set and the disclosure is constrained.
+func (d *Disclosure) audit(kind access.Kind, granted bool, caller *Impulse, code ...any) {
+	if d.Audit == nil || d.Constraint == relationally.Open {
+		return
+	}
+
+	entry := Access{
+		Kind:     kind,
+		Granted:  granted,
+		CodeHash: hashCode(code...),
+	}
+	if caller != nil {
+		entry.Bridge = slices.Clone(caller.Bridge)
+	}
+	d.Audit.Record(time.Now(), entry)
+}
+
+// AuditTrail returns every recorded Access between the provided moments whose Bridge begins with the provided steps.
+// If no steps are provided, every access in the period is returned.
+//
+// NOTE: If the disclosure has no Audit buffer, this returns nil.
+func (d *Disclosure) AuditTrail(from, to time.Time, prefix ...any) []instant[Access] {
+	if d.Audit == nil {
+		return nil
+	}
+
+	trail := d.Audit.Between(from, to)
+	if len(prefix) == 0 {
+		return trail
+	}
+	out := make([]instant[Access], 0, len(trail))
+	for _, inst := range trail {
+		if hasPrefix(inst.Element.Bridge, prefix...) {
+			out = append(out, inst)
+		}
+	}
+	return out
+}
+
+// hasPrefix tests if the path begins with the provided steps, comparing each by its string form.
+func hasPrefix(path Path, prefix ...any) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i, step := range prefix {
+		if Stringify(path[i]) != Stringify(step) {
+			return false
+		}
+	}
+	return true
+}
+
+// auditKey is the per-process key every CodeHash is an HMAC of.
+var auditKey = func() []byte {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Errorf("unable to key the audit's code hashes: %w", err))
+	}
+	return key
+}()
+
+// hashCode returns the CodeHash of the provided code - see Access.
+func hashCode(code ...any) string {
+	if len(code) == 0 || code[0] == nil {
+		return ""
+	}
+
+	var text string
+	if Stringable(code[0]) {
+		text = Stringify(code[0])
+	} else {
+		text = fmt.Sprintf("%T", code[0])
+	}
+	mac := hmac.New(sha256.New, auditKey)
+	mac.Write([]byte(text))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,69 @@
+package std
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/access"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+func TestAuditTrail(t *testing.T) {
+	window := time.Hour
+	d := &Disclosure{Constraint: relationally.Exclusive, Audit: NewTemporalBuffer[Access](&window)}
+	d.Code("secret")
+	thought, _ := NewThought(1, d)
+	caller := &Impulse{Bridge: Path{"Server", "Handler"}}
+
+	start := time.Now()
+	_, _ = thought.RevealAs(caller, "secret")
+	_, _ = thought.Reveal("wrong")
+	_ = thought.DescribeAs(caller, 2, "wrong")
+
+	trail := d.AuditTrail(start, time.Now())
+	if len(trail) != 3 {
+		t.Fatalf("recorded %d accesses, want 3", len(trail))
+	}
+	want := []Access{
+		{Kind: access.Reveal, Granted: true, Bridge: caller.Bridge, CodeHash: hashCode("secret")},
+		{Kind: access.Reveal, Granted: false, CodeHash: hashCode("wrong")},
+		{Kind: access.Describe, Granted: false, Bridge: caller.Bridge, CodeHash: hashCode("wrong")},
+	}
+	for i, inst := range trail {
+		got := inst.Element
+		if got.Kind != want[i].Kind || got.Granted != want[i].Granted || got.CodeHash != want[i].CodeHash || got.Bridge.String() != want[i].Bridge.String() {
+			t.Fatalf("access %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	if identified := d.AuditTrail(start, time.Now(), "Server"); len(identified) != 2 {
+		t.Fatalf("found %d accesses beneath the caller's bridge, want 2", len(identified))
+	}
+}
+
+func TestAuditIgnoresOpenThoughts(t *testing.T) {
+	window := time.Hour
+	d := &Disclosure{Audit: NewTemporalBuffer[Access](&window)}
+	thought, _ := NewThought(1, d)
+	_, _ = thought.Reveal()
+	if d.Audit.Len() != 0 {
+		t.Fatal("expected an open thought to not be audited")
+	}
+}
+
+func TestAuditHashesAreKeyed(t *testing.T) {
+	if hashCode() != "" || hashCode(nil) != "" {
+		t.Error("expected no code to hash to an empty string")
+	}
+	if hashCode("secret") != hashCode("secret") || hashCode("secret") == hashCode("wrong") {
+		t.Error("expected hashes to be comparable within the process")
+	}
+
+	// A plain hash of the code could be brute forced offline, so the code must never be hashed without the key
+	plain := sha256.Sum256([]byte("secret"))
+	if hashCode("secret") == hex.EncodeToString(plain[:]) {
+		t.Error("expected the code hash to be keyed")
+	}
+}
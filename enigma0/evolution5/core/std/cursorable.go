@@ -1,5 +1,9 @@
 package std
 
+import (
+	"reflect"
+)
+
 // A Cursorable entity is one that can traverse an abstract space using relative or absolute motion.
 //
 // NOTE: Think of the very cursor currently in your IDE being driven between points arbitrarily while selecting data.
@@ -29,6 +33,8 @@ type Cursorable[TOut any] interface {
 	//
 	// NOTE: stride will be revealed between each step, allowing you to make a "dynamic stride" using function providers.
 	//
+	// NOTE: A func() (~T, T) provider yields each step alongside the stride to walk to it at.
+	//
 	// NOTE: You may alternatively provide a slice of direct TOut points to traverse, or an individual TOut for a single element.
 	WalkAlong(steps any, stride any, relative bool) Cursorable[TOut]
 
@@ -41,6 +47,50 @@ type Cursorable[TOut any] interface {
 	Yield() []TOut
 }
 
+// IntegerOf reveals any integer - or function providing one - as an int, such as the positions and strides given to a
+// Cursorable.  If the value can't be revealed as an integer, this returns false.
+func IntegerOf(value any) (int, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return int(v.Uint()), true
+	case reflect.Func:
+		if v.IsNil() || v.Type().NumIn() != 0 || v.Type().NumOut() != 1 {
+			return 0, false
+		}
+		return IntegerOf(v.Call(nil)[0].Interface())
+	default:
+		return 0, false
+	}
+}
+
+// FlowDistance returns the signed distance a boundary.Flow cursor travels around 'n' elements to cover the provided
+// distance - the shortest path around the loop, or its complement if taking the "long way 'round."
+//
+// NOTE: When both paths are equally long, the shortest path travels forwards.  A distance of zero is already the
+// shortest path, so its long way 'round is a full loop backwards.
+func FlowDistance(distance int, n int, long bool) int {
+	if n <= 0 {
+		return 0
+	}
+	shortest := (distance%n + n) % n
+	if shortest*2 > n {
+		shortest -= n
+	}
+	switch {
+	case !long:
+		return shortest
+	case shortest > 0:
+		return shortest - n
+	case shortest < 0:
+		return shortest + n
+	default:
+		return -n
+	}
+}
+
 /*
 # Six Degrees of Semantic Freedom
 
@@ -74,7 +124,7 @@ Jumping --
 [[~-42]] <- Jump(-42) - Yields the element -42 positions away
 
 [[42]] <- JumpTo(42) - Yields the element 42 positions from 0
-[[-42]] <- JumpTo(-42) - Yields the element -42 positions from 0
+[[-42]] <- JumpTo(-42) - Yields the element -42 positions from 0 (see "bounded contexts")
 
 Walking --
 [[~42, 0]] <- Walk(42, 0) - Yields nothing, as a zero stride yields zero elements
@@ -86,21 +136,23 @@ Walking --
 [[~-42, 1]] <- Walk(-42, 1) - Yields the elements from here to "-42 positions away" at a stride of 1
 
 [[ 42, 1]] <- WalkTo(42, 1) - Yields the elements from here to "position 42 from zero" at a stride of 1
-[[-42, 1]] <- WalkTo(-42, 1) - Yields the elements from here to "position -42 from zero" at a stride of 1
+[[-42, 1]] <- WalkTo(-42, 1) - Yields the elements from here to "position -42 from zero" at a stride of 1 (see "bounded contexts")
 
 [[~42, 5]] <- Walk(42, 5) - Yields the elements from here to "42 positions away" at a stride of 5
 [[~-42, 5]] <- Walk(-42, 5) - Yields the elements from here to "-42 positions away" at a stride of 5
 
 [[ 42, 5]] <- WalkTo(42, 5) - Yields the elements from here to "position 42 from zero" at a stride of 5
-[[-42, 5]] <- WalkTo(-42, 5) - Yields the elements from here to "position -42 from zero" at a stride of 5
+[[-42, 5]] <- WalkTo(-42, 5) - Yields the elements from here to "position -42 from zero" at a stride of 5 (see "bounded contexts")
 
 [[ 42, -5]] <- WalkTo(42, -5) - Yields the elements from here to "position 42 from zero" at a stride of -5 (see "bounded contexts")
 [[~42, -5]] <- Walk(42, -5) - Yields the elements from here to "42 positions away" at a stride of -5 (see "bounded contexts")
 
 Bounded Contexts -
-Most data is naturally bounded by its own size, so cursor movement naturally provides Python-style 'tail indexing.'
-In addition, a negative -stride- indicates to traverse the inverse of the shortest path, causing the cursor to
-take the "long way" 'round.  That being said, if your cursor is traversing a mathematically infinite space, a
+Most data is naturally bounded by its own size, so a flowing cursor naturally provides Python-style 'tail indexing' -
+<-42> yields the element 42 positions from the end.  A panicking cursor instead treats a negative absolute position
+as out of range, and a clamped cursor saturates it to the first element.  Within a flowing cursor, a positive stride
+traverses the shortest path (the distance modulo the data's length) while a negative -stride- indicates to traverse
+its inverse, causing the cursor to take the "long way" 'round.  That being said, if your cursor is traversing a mathematically infinite space, a
 negative stride is an 'undefined' operation that should cause a panic.
 
 This has an important caveat: saturated contexts!  In a "clamped" environment, there's only -one- direction that
@@ -2,15 +2,39 @@ package std
 
 import (
 	"fmt"
+	"reflect"
+	"time"
 
 	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
 )
 
 // A Disclosure describes the codified conditions and constraints behind Others' access to a Thought.
+//
+// NOTE: Beyond its primary code, a disclosure can Grant any number of additional codes - each optionally expiring - which
+// can later be Revoke'd.  To replace the primary code without locking out those still using it, please use Rotate.
 type Disclosure struct {
 	// Constraint defines the psychological relationship applied to this Thought
 	Constraint relationally.Constrained
-	code       any
+
+	// Audit, if set, records every Access to a relationally.Inclusive or relationally.Exclusive Thought - see AuditTrail.
+	//
+	// NOTE: The trail only lasts as long as the buffer's retention policies allow, and a buffer created without a window
+	// observes just atlas.ObservanceWindow - far too brief for compliance.  Please provide a window spanning your audit period.
+	Audit *TemporalBuffer[Access]
+
+	code   any
+	grants []grant
+	gate   Gate
+}
+
+// A grant is an additional code a Disclosure accepts, until it optionally expires.
+type grant struct {
+	code    any
+	expires *time.Time
+}
+
+func (g grant) expired(now time.Time) bool {
+	return g.expires != nil && !now.Before(*g.expires)
 }
 
 func (d *Disclosure) sanityCheck(codes ...any) {
@@ -26,37 +50,146 @@ func (d *Disclosure) sanityCheck(codes ...any) {
 	}
 }
 
+// validCode panics if the provided value is neither a Stringable nor Negotiable type.
+func validCode(value any) {
+	if !negotiable(value) && !Stringable(value) {
+		panic(fmt.Errorf("the provided code is a %T - which is not a std.Stringable or std.Negotiable type", value))
+	}
+}
+
 // Code sets and/or gets the Disclosure code.  If no value is provided, this just returns the code - if values
 // are provided, the first value is set as the disclosure code before returning it.
 //
 // NOTE: This will panic if provided anything but a Stringable or Negotiable type!
 func (d *Disclosure) Code(value ...any) any {
+	d.gate.Lock()
+	defer d.gate.Unlock()
+
 	if len(value) > 0 {
-		if negotiable(value[0]) || Stringable(value[0]) {
-			d.code = value[0]
-		} else {
-			panic(fmt.Errorf("the provided code is a %T - which is not a std.Stringable or std.Negotiable type", value[0]))
+		validCode(value[0])
+		d.code = value[0]
+	}
+	return d.code
+}
+
+// Grant adds another code which passes the Disclosure's Check alongside its primary code.  If a duration is provided,
+// the code expires once it has elapsed - otherwise, it remains valid until it's Revoke'd.
+//
+// NOTE: This will panic if provided anything but a Stringable or Negotiable type!
+func (d *Disclosure) Grant(code any, duration ...time.Duration) {
+	validCode(code)
+
+	d.gate.Lock()
+	defer d.gate.Unlock()
+
+	g := grant{code: code}
+	if len(duration) > 0 {
+		expires := time.Now().Add(duration[0])
+		g.expires = &expires
+	}
+	d.grants = append(d.grants, g)
+}
+
+// Revoke removes every granted code equivalent to the provided code, returning whether any were found.
+//
+// NOTE: The primary code can't be revoked - to replace it, please use Code or Rotate.
+func (d *Disclosure) Revoke(code any) bool {
+	d.gate.Lock()
+	defer d.gate.Unlock()
+
+	found := false
+	kept := d.grants[:0]
+	for _, g := range d.grants {
+		if equivalentCodes(g.code, code) {
+			found = true
+			continue
 		}
+		kept = append(kept, g)
 	}
-	return value
+	clear(d.grants[len(kept):])
+	d.grants = kept
+	return found
 }
 
-// Check validates the provided code.
+// Rotate replaces the primary code with the provided code, while the prior code remains granted for the provided
+// grace period - allowing anyone still using it time to catch up.  A grace period of 0 or less revokes it immediately.
+//
+// NOTE: This will panic if provided anything but a Stringable or Negotiable type!
+func (d *Disclosure) Rotate(code any, grace time.Duration) {
+	validCode(code)
+
+	d.gate.Lock()
+	defer d.gate.Unlock()
+
+	if grace > 0 {
+		expires := time.Now().Add(grace)
+		d.grants = append(d.grants, grant{code: d.code, expires: &expires})
+	}
+	d.code = code
+}
+
+// Check validates the provided code against the primary code and every unexpired granted code.
 //
 // 0. If a nil (or absent) code is provided, a string comparison of "" is made
 //
-// 1. If a Negotiable code is provided, the disclosure.code.Negotiate(code) must yield "true".
+// 1. If the disclosure's code is Negotiable, the disclosure.code.Negotiate(code) must yield "true".
 //
 // 2. All other provided codes must be Stringable, and a string comparison is performed for equivalency
 //
 // 3. Otherwise, this will panic during its "sanity check"
 func (d *Disclosure) Check(code ...any) bool {
-	d.sanityCheck()
+	d.gate.Lock()
+	defer d.gate.Unlock()
+	d.sanityCheck(code...)
 
-	if len(code) == 0 && (d.code == nil || d.code == "") {
+	if codeMatches(d.code, code...) {
 		return true
-	} else if len(code) > 0 {
-		return Stringify(code[0]) == Stringify(d.code)
 	}
-	return false
+
+	now := time.Now()
+	kept := d.grants[:0]
+	found := false
+	for _, g := range d.grants {
+		if g.expired(now) {
+			// Expired grants are pruned as they're found
+			continue
+		}
+		kept = append(kept, g)
+		if !found && codeMatches(g.code, code...) {
+			found = true
+		}
+	}
+	clear(d.grants[len(kept):])
+	d.grants = kept
+	return found
+}
+
+// codeMatches tests the provided code against an expected code, negotiating if the expected code is Negotiable.
+//
+// NOTE: A provided code which isn't Stringable never matches an expected code which is.
+func codeMatches(expected any, code ...any) bool {
+	var provided any
+	if len(code) > 0 {
+		provided = code[0]
+	}
+
+	if n, ok := expected.(Negotiable); ok {
+		return n.Negotiate(provided)
+	}
+	if !Stringable(provided, expected) {
+		// A Negotiable code has no string form to compare against
+		return false
+	}
+	return Stringify(provided) == Stringify(expected)
+}
+
+// equivalentCodes tests if two codes are the same - Stringable codes by their string form and all others by identity.
+func equivalentCodes(a, b any) bool {
+	if Stringable(a, b) {
+		return Stringify(a) == Stringify(b)
+	}
+	if a == nil || b == nil || !reflect.TypeOf(a).Comparable() || !reflect.TypeOf(b).Comparable() {
+		return false
+	}
+	return a == b
 }
@@ -0,0 +1,77 @@
+package std
+
+import (
+	"testing"
+	"time"
+)
+
+// A negotiator accepts any code equal to its own.
+type negotiator struct {
+	accepts any
+}
+
+func (n negotiator) Negotiate(code any) bool {
+	return code == n.accepts
+}
+
+func TestDisclosureCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		primary any
+		grants  []any
+		code    []any
+		want    bool
+	}{
+		{name: "absent code against no code", primary: nil, want: true},
+		{name: "absent code against a string", primary: "x", want: false},
+		{name: "matching string", primary: "x", code: []any{"x"}, want: true},
+		{name: "matching stringable number", primary: "42", code: []any{42}, want: true},
+		{name: "mismatched string", primary: "x", code: []any{"y"}, want: false},
+		{name: "negotiable against a string", primary: "x", code: []any{negotiator{}}, want: false},
+		{name: "negotiable against a string grant", primary: "x", grants: []any{"y"}, code: []any{negotiator{}}, want: false},
+		{name: "negotiated primary", primary: negotiator{accepts: "x"}, code: []any{"x"}, want: true},
+		{name: "negotiated primary refused", primary: negotiator{accepts: "x"}, code: []any{"y"}, want: false},
+		{name: "granted string", primary: "x", grants: []any{"y"}, code: []any{"y"}, want: true},
+		{name: "negotiated grant", primary: "x", grants: []any{negotiator{accepts: 7}}, code: []any{7}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Disclosure{}
+			d.Code(tt.primary)
+			for _, code := range tt.grants {
+				d.Grant(code)
+			}
+			if got := d.Check(tt.code...); got != tt.want {
+				t.Fatalf("Check(%v) = %v, want %v", tt.code, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisclosureGrants(t *testing.T) {
+	d := &Disclosure{}
+	d.Code("primary")
+	d.Grant("forever")
+	d.Grant("brief", time.Millisecond)
+	d.Grant(negotiator{accepts: "negotiated"})
+
+	if !d.Check("forever") || !d.Check("brief") || !d.Check("negotiated") {
+		t.Fatal("expected every granted code to pass")
+	}
+	time.Sleep(5 * time.Millisecond)
+	if d.Check("brief") {
+		t.Fatal("expected an expired grant to fail")
+	}
+	if !d.Revoke("forever") || d.Check("forever") {
+		t.Fatal("expected a revoked grant to fail")
+	}
+	if d.Revoke(negotiator{accepts: "other"}) {
+		t.Fatal("expected an unknown negotiable grant to not be revoked")
+	}
+
+	d.Rotate("rotated", time.Hour)
+	if !d.Check("rotated") || !d.Check("primary") {
+		t.Fatal("expected both the rotated and prior codes to pass during the grace period")
+	}
+}
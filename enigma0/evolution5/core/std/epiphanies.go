@@ -1,5 +1,22 @@
 package std
 
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"time"
+
+	"git.ignitelabs.net/janos/core/sys/atlas"
+)
+
 /**
 This file holds the standard "epiphanies" - which are known operations to materialize a larger structure
 from its idealized form.  For example, images or archives which need to be materialized into their more
@@ -8,23 +25,259 @@ complex structures before others can understand and process their contents.
 
 type epiphanies struct{}
 
+// Epiphanies provides the standard epiphanies, each of which is described by the encoded bytes it's created from.
+//
+// NOTE: The error returned alongside each is whatever describing those bytes raised.
 var Epiphanies epiphanies
 
-//func (e epiphanies) JPEG(encoded []byte, model color.Model, decay ...time.Duration) *Epiphany[[]byte, image.Image] {
-//	d := atlas.DecayPeriod
-//	if len(decay) > 0 {
-//		d = decay[0]
-//	}
-//	epi := NewEpiphany[[]byte, image.Image](func(ideal []byte) (material image.Image, err error) {
-//		img, err := jpeg.Decode(bytes.NewReader(ideal))
-//		if err != nil {
-//			return nil, err
-//		}
-//		return support.ToColorModel(img), nil
-//	}, d)
-//	_ = epi.Describe(encoded)
-//	return epi
-//}
+// An Animation is the materialized form of an animated image, such as a GIF.
+type Animation struct {
+	// Frames holds each fully composed frame of the animation, in order.
+	Frames []image.Image
+
+	// Delays holds how long each frame should be displayed for.
+	Delays []time.Duration
+
+	// LoopCount holds how many times the animation should loop - where 0 loops forever and -1 shows each frame only once.
+	LoopCount int
+}
+
+func decayOf(decay ...time.Duration) time.Duration {
+	if len(decay) > 0 {
+		return decay[0]
+	}
+	return atlas.DecayPeriod
+}
+
+// JPEG creates an Epiphany which materializes the provided JPEG bytes into an image of the provided color.Model.
 //
-//func (e epiphanies) PNG(encoded []byte, model color.Model) (Epiphany[[]byte, image.Image], error) {
-//}
+// NOTE: If no model is provided (nil), the decoded image is materialized as is.  Revisions are re-encoded at the default JPEG quality.
+func (e epiphanies) JPEG(encoded []byte, model color.Model, decay ...time.Duration) (*Epiphany[[]byte, image.Image], error) {
+	epi := NewEpiphany[[]byte, image.Image](func(ideal []byte) (image.Image, error) {
+		img, err := jpeg.Decode(bytes.NewReader(ideal))
+		if err != nil {
+			return nil, err
+		}
+		return toColorModel(img, model), nil
+	}, decayOf(decay...), func(material image.Image) ([]byte, error) {
+		var buf bytes.Buffer
+		err := jpeg.Encode(&buf, material, nil)
+		return buf.Bytes(), err
+	})
+	return epi, epi.Describe(encoded)
+}
+
+// PNG creates an Epiphany which materializes the provided PNG bytes into an image of the provided color.Model.
+//
+// NOTE: If no model is provided (nil), the decoded image is materialized as is.
+func (e epiphanies) PNG(encoded []byte, model color.Model, decay ...time.Duration) (*Epiphany[[]byte, image.Image], error) {
+	epi := NewEpiphany[[]byte, image.Image](func(ideal []byte) (image.Image, error) {
+		img, err := png.Decode(bytes.NewReader(ideal))
+		if err != nil {
+			return nil, err
+		}
+		return toColorModel(img, model), nil
+	}, decayOf(decay...), func(material image.Image) ([]byte, error) {
+		var buf bytes.Buffer
+		err := png.Encode(&buf, material)
+		return buf.Bytes(), err
+	})
+	return epi, epi.Describe(encoded)
+}
+
+// GIF creates an Epiphany which materializes the provided GIF bytes into an Animation of fully composed frames, each
+// of the provided color.Model.
+//
+// NOTE: If no model is provided (nil), each frame is materialized in RGBA.
+func (e epiphanies) GIF(encoded []byte, model color.Model, decay ...time.Duration) (*Epiphany[[]byte, Animation], error) {
+	epi := NewEpiphany[[]byte, Animation](func(ideal []byte) (Animation, error) {
+		g, err := gif.DecodeAll(bytes.NewReader(ideal))
+		if err != nil {
+			return Animation{}, err
+		}
+
+		bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+		if bounds.Empty() && len(g.Image) > 0 {
+			bounds = g.Image[0].Bounds()
+		}
+
+		// Frames only describe what changed, so each must be drawn atop the canvas as the prior frame's disposal left it
+		canvas := image.NewRGBA(bounds)
+		animation := Animation{
+			Frames:    make([]image.Image, len(g.Image)),
+			Delays:    make([]time.Duration, len(g.Image)),
+			LoopCount: g.LoopCount,
+		}
+		for i, frame := range g.Image {
+			var previous *image.RGBA
+			disposal := byte(0)
+			if i < len(g.Disposal) {
+				disposal = g.Disposal[i]
+			}
+			if disposal == gif.DisposalPrevious {
+				previous = image.NewRGBA(bounds)
+				draw.Draw(previous, bounds, canvas, bounds.Min, draw.Src)
+			}
+
+			draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+			composed := image.NewRGBA(bounds)
+			draw.Draw(composed, bounds, canvas, bounds.Min, draw.Src)
+			animation.Frames[i] = toColorModel(composed, model)
+			if i < len(g.Delay) {
+				// GIF delays are measured in hundredths of a second
+				animation.Delays[i] = time.Duration(g.Delay[i]) * 10 * time.Millisecond
+			}
+
+			switch disposal {
+			case gif.DisposalBackground:
+				draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+			case gif.DisposalPrevious:
+				canvas = previous
+			}
+		}
+		return animation, nil
+	}, decayOf(decay...))
+	return epi, epi.Describe(encoded)
+}
+
+// Gzip creates an Epiphany which materializes the provided gzip bytes into their decompressed form.
+//
+// NOTE: Revisions are recompressed at the default compression level.
+func (e epiphanies) Gzip(encoded []byte, decay ...time.Duration) (*Epiphany[[]byte, []byte], error) {
+	epi := NewEpiphany[[]byte, []byte](func(ideal []byte) ([]byte, error) {
+		r, err := gzip.NewReader(bytes.NewReader(ideal))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	}, decayOf(decay...), func(material []byte) ([]byte, error) {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(material); err != nil {
+			return nil, err
+		}
+		err := w.Close()
+		return buf.Bytes(), err
+	})
+	return epi, epi.Describe(encoded)
+}
+
+// Tar creates an Epiphany which materializes the provided tar bytes into a map of each regular file's name to its contents.
+func (e epiphanies) Tar(encoded []byte, decay ...time.Duration) (*Epiphany[[]byte, map[string][]byte], error) {
+	epi := NewEpiphany[[]byte, map[string][]byte](func(ideal []byte) (map[string][]byte, error) {
+		files := make(map[string][]byte)
+		r := tar.NewReader(bytes.NewReader(ideal))
+		for {
+			header, err := r.Next()
+			if err == io.EOF {
+				return files, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if header.Typeflag != tar.TypeReg {
+				continue
+			}
+			contents, err := io.ReadAll(r)
+			if err != nil {
+				return nil, err
+			}
+			files[header.Name] = contents
+		}
+	}, decayOf(decay...))
+	return epi, epi.Describe(encoded)
+}
+
+// Zip creates an Epiphany which materializes the provided zip bytes into a map of each file's name to its contents.
+func (e epiphanies) Zip(encoded []byte, decay ...time.Duration) (*Epiphany[[]byte, map[string][]byte], error) {
+	epi := NewEpiphany[[]byte, map[string][]byte](func(ideal []byte) (map[string][]byte, error) {
+		r, err := zip.NewReader(bytes.NewReader(ideal), int64(len(ideal)))
+		if err != nil {
+			return nil, err
+		}
+		files := make(map[string][]byte)
+		for _, file := range r.File {
+			if file.FileInfo().IsDir() {
+				continue
+			}
+			contents, err := readZipFile(file)
+			if err != nil {
+				return nil, err
+			}
+			files[file.Name] = contents
+		}
+		return files, nil
+	}, decayOf(decay...))
+	return epi, epi.Describe(encoded)
+}
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// toColorModel normalizes the provided image to the provided color.Model.  The standard library's models are drawn into
+// their native image types, while any other model is converted lazily as each pixel is read.
+//
+// NOTE: If the model is nil, or the image already uses it, the image is returned as is.
+func toColorModel(img image.Image, model color.Model) image.Image {
+	if model == nil {
+		return img
+	}
+
+	// NOTE: Palettes are slices, which can't be compared as interfaces - so they're handled before anything else
+	bounds := img.Bounds()
+	if palette, ok := model.(color.Palette); ok {
+		out := image.NewPaletted(bounds, palette)
+		draw.Draw(out, bounds, img, bounds.Min, draw.Src)
+		return out
+	}
+	if _, ok := img.ColorModel().(color.Palette); !ok && img.ColorModel() == model {
+		return img
+	}
+
+	var out draw.Image
+	switch model {
+	case color.RGBAModel:
+		out = image.NewRGBA(bounds)
+	case color.RGBA64Model:
+		out = image.NewRGBA64(bounds)
+	case color.NRGBAModel:
+		out = image.NewNRGBA(bounds)
+	case color.NRGBA64Model:
+		out = image.NewNRGBA64(bounds)
+	case color.GrayModel:
+		out = image.NewGray(bounds)
+	case color.Gray16Model:
+		out = image.NewGray16(bounds)
+	case color.AlphaModel:
+		out = image.NewAlpha(bounds)
+	case color.Alpha16Model:
+		out = image.NewAlpha16(bounds)
+	case color.CMYKModel:
+		out = image.NewCMYK(bounds)
+	default:
+		return modeledImage{Image: img, model: model}
+	}
+	draw.Draw(out, bounds, img, bounds.Min, draw.Src)
+	return out
+}
+
+// A modeledImage converts each pixel of the underlying image into its color.Model as it's read.
+type modeledImage struct {
+	image.Image
+	model color.Model
+}
+
+func (m modeledImage) ColorModel() color.Model {
+	return m.model
+}
+
+func (m modeledImage) At(x, y int) color.Color {
+	return m.model.Convert(m.Image.At(x, y))
+}
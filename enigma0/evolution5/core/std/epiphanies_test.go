@@ -0,0 +1,224 @@
+package std
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"time"
+)
+
+// checkerboard creates a small image alternating between black and white pixels.
+func checkerboard() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if (x+y)%2 == 0 {
+				img.Set(x, y, color.White)
+			} else {
+				img.Set(x, y, color.Black)
+			}
+		}
+	}
+	return img
+}
+
+func TestEpiphaniesPNG(t *testing.T) {
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, checkerboard()); err != nil {
+		t.Fatal(err)
+	}
+	epi, err := Epiphanies.PNG(encoded.Bytes(), color.GrayModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := epi.Reveal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.ColorModel() != color.GrayModel {
+		t.Errorf("materialized a %T, want a gray image", img)
+	}
+	if got := color.GrayModel.Convert(img.At(0, 0)).(color.Gray).Y; got != 255 {
+		t.Errorf("materialized a top left luminance of %d, want 255", got)
+	}
+
+	// Revisions are re-encoded as PNG bytes
+	revision := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	if err = epi.Revise(revision); err != nil {
+		t.Fatal(err)
+	}
+	if err = epi.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	ideal, _ := epi.thought.Reveal()
+	if config, err := png.DecodeConfig(bytes.NewReader(ideal)); err != nil || config.Width != 2 {
+		t.Errorf("committed %d pixels wide (%v), want 2", config.Width, err)
+	}
+}
+
+func TestEpiphaniesJPEG(t *testing.T) {
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, checkerboard(), nil); err != nil {
+		t.Fatal(err)
+	}
+	epi, err := Epiphanies.JPEG(encoded.Bytes(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := epi.Reveal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 8, 8) {
+		t.Errorf("materialized bounds of %v, want 8x8", img.Bounds())
+	}
+
+	if err = epi.Revise(image.NewRGBA(image.Rect(0, 0, 3, 3))); err != nil {
+		t.Fatal(err)
+	}
+	if err = epi.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	ideal, _ := epi.thought.Reveal()
+	if config, err := jpeg.DecodeConfig(bytes.NewReader(ideal)); err != nil || config.Width != 3 {
+		t.Errorf("committed %d pixels wide (%v), want 3", config.Width, err)
+	}
+}
+
+func TestEpiphaniesGIF(t *testing.T) {
+	// Each frame paints a single pixel, so composing them should accumulate a diagonal line
+	g := &gif.GIF{Config: image.Config{Width: 3, Height: 3}, LoopCount: 2}
+	for i := 0; i < 3; i++ {
+		frame := image.NewPaletted(image.Rect(i, i, i+1, i+1), palette.Plan9)
+		frame.Set(i, i, color.White)
+		g.Image = append(g.Image, frame)
+		g.Delay = append(g.Delay, 5)
+		g.Disposal = append(g.Disposal, gif.DisposalNone)
+	}
+	var encoded bytes.Buffer
+	if err := gif.EncodeAll(&encoded, g); err != nil {
+		t.Fatal(err)
+	}
+
+	epi, err := Epiphanies.GIF(encoded.Bytes(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	animation, err := epi.Reveal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(animation.Frames) != 3 || animation.LoopCount != 2 {
+		t.Fatalf("materialized %d frames looping %d times, want 3 looping twice", len(animation.Frames), animation.LoopCount)
+	}
+	for i, delay := range animation.Delays {
+		if delay != 50*time.Millisecond {
+			t.Errorf("frame %d delays %v, want 50ms", i, delay)
+		}
+	}
+	last := animation.Frames[2]
+	for i := 0; i < 3; i++ {
+		if _, _, _, a := last.At(i, i).RGBA(); a == 0 {
+			t.Errorf("expected the last frame to have composed the pixel at %d,%d", i, i)
+		}
+	}
+	if err = epi.Revise(animation); err == nil {
+		t.Error("expected revising a GIF to be irreversible")
+	}
+}
+
+func TestEpiphaniesGzip(t *testing.T) {
+	var encoded bytes.Buffer
+	w := gzip.NewWriter(&encoded)
+	w.Write([]byte("hello, world"))
+	w.Close()
+
+	epi, err := Epiphanies.Gzip(encoded.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain, err := epi.Reveal(); err != nil || string(plain) != "hello, world" {
+		t.Fatalf("materialized %q (%v), want \"hello, world\"", plain, err)
+	}
+
+	if err = epi.Revise([]byte("goodbye")); err != nil {
+		t.Fatal(err)
+	}
+	if err = epi.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	ideal, _ := epi.thought.Reveal()
+	if err = epi.Describe(ideal); err != nil {
+		t.Fatal(err)
+	}
+	if plain, err := epi.Reveal(); err != nil || string(plain) != "goodbye" {
+		t.Fatalf("rematerialized %q (%v), want \"goodbye\"", plain, err)
+	}
+}
+
+func TestEpiphaniesTar(t *testing.T) {
+	var encoded bytes.Buffer
+	w := tar.NewWriter(&encoded)
+	w.WriteHeader(&tar.Header{Name: "folder/", Typeflag: tar.TypeDir, Mode: 0755})
+	w.WriteHeader(&tar.Header{Name: "folder/file.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: 5})
+	w.Write([]byte("hello"))
+	w.Close()
+
+	epi, err := Epiphanies.Tar(encoded.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := epi.Reveal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || string(files["folder/file.txt"]) != "hello" {
+		t.Errorf("materialized %v, want only folder/file.txt", files)
+	}
+}
+
+func TestEpiphaniesZip(t *testing.T) {
+	var encoded bytes.Buffer
+	w := zip.NewWriter(&encoded)
+	w.Create("folder/")
+	file, _ := w.Create("folder/file.txt")
+	file.Write([]byte("hello"))
+	w.Close()
+
+	epi, err := Epiphanies.Zip(encoded.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := epi.Reveal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || string(files["folder/file.txt"]) != "hello" {
+		t.Errorf("materialized %v, want only folder/file.txt", files)
+	}
+}
+
+func TestEpiphaniesRejectCorruptBytes(t *testing.T) {
+	corrupt := []byte("definitely not encoded")
+	reveals := map[string]func() error{
+		"JPEG": func() error { epi, _ := Epiphanies.JPEG(corrupt, nil); _, err := epi.Reveal(); return err },
+		"PNG":  func() error { epi, _ := Epiphanies.PNG(corrupt, nil); _, err := epi.Reveal(); return err },
+		"GIF":  func() error { epi, _ := Epiphanies.GIF(corrupt, nil); _, err := epi.Reveal(); return err },
+		"Gzip": func() error { epi, _ := Epiphanies.Gzip(corrupt); _, err := epi.Reveal(); return err },
+		"Tar":  func() error { epi, _ := Epiphanies.Tar(corrupt); _, err := epi.Reveal(); return err },
+		"Zip":  func() error { epi, _ := Epiphanies.Zip(corrupt); _, err := epi.Reveal(); return err },
+	}
+	for name, reveal := range reveals {
+		if err := reveal(); err == nil {
+			t.Errorf("expected revealing corrupt %s bytes to fail", name)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package std
 
 import (
+	"context"
 	"sync"
 	"time"
 
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
 	"git.ignitelabs.net/janos/core"
 )
 
@@ -14,24 +16,66 @@ import (
 // result out of memory.
 //
 // Don't overthink it - that's really it =)
+//
+// NOTE: If the epiphany is given a means of idealizing its revelation, it becomes bidirectional - meaning any Revise'd
+// (or MarkDirty'd) revelation is written back into its idealized form when it decays or is explicitly Commit'd.
+//
+// NOTE: Materialization happens outside the epiphany's gate, and every concurrent Reveal shares the same in-flight
+// materialization - so concurrent readers never materialize the same description twice.
 type Epiphany[TIdealized any, TMaterialized any] struct {
-	thought    Thought[TIdealized]
+	thought    *Thought[TIdealized]
 	revelation *TMaterialized
+	stale      *TMaterialized
+	inflight   *materialization[TMaterialized]
+	generation uint64
 
 	gate        *Gate
 	motivate    chan any
 	materialize func(TIdealized) (TMaterialized, error)
+	idealize    func(TMaterialized) (TIdealized, error)
+	sizeOf      func(TMaterialized) uint
+	size        uint
 	decay       time.Duration
+
+	dirty     bool
+	dirtyCode []any
+}
+
+// A materialization is a single in-flight call to an Epiphany's 'materialize' function, which every concurrent Reveal waits upon.
+type materialization[T any] struct {
+	done     chan struct{}
+	material T
+	size     uint
+	err      error
 }
 
+// These statistics are shared by every Epiphany - see Performance.
+var (
+	epiphanyReveals          = Performance("Epiphanies", "Reveal")
+	epiphanyMaterializations = Performance("Epiphanies", "Materialize")
+	epiphanyDecays           = Performance("Epiphanies", "Decay")
+	epiphanyDecayFailures    = Performance("Epiphanies", "DecayFailure")
+)
+
 // NewEpiphany creates a new Epiphany which can 'materialize' into something more complex on demand, then 'decay' back to
 // an idealized form after the provided amount of time with no activity.
-func NewEpiphany[TIdealized, TMaterialized any](materialize func(TIdealized) (TMaterialized, error), decay time.Duration) *Epiphany[TIdealized, TMaterialized] {
+//
+// NOTE: If you'd like the epiphany to be bidirectional, you may optionally provide an 'idealize' function to write
+// modified revelations back into their idealized form.
+func NewEpiphany[TIdealized, TMaterialized any](materialize func(TIdealized) (TMaterialized, error), decay time.Duration, idealize ...func(TMaterialized) (TIdealized, error)) *Epiphany[TIdealized, TMaterialized] {
+	var ideal TIdealized
+	thought, _ := NewThought(ideal)
+
 	e := &Epiphany[TIdealized, TMaterialized]{
+		thought:     thought,
+		gate:        new(Gate),
 		motivate:    make(chan any),
 		decay:       decay,
 		materialize: materialize,
 	}
+	if len(idealize) > 0 {
+		e.idealize = idealize[0]
+	}
 
 	go func() {
 		var timer *time.Timer
@@ -54,15 +98,9 @@ func NewEpiphany[TIdealized, TMaterialized any](materialize func(TIdealized) (TM
 				if timer != nil {
 					timer.Stop()
 				}
-				timer = time.AfterFunc(e.decay, func() {
-					e.gate.Lock()
-					e.revelation = nil
-					e.gate.Unlock()
+				timer = time.AfterFunc(e.decay, e.decompose)
 
-					// TODO: Cache the motivation as a "Decay" in std.Path{"Performance", "Epiphanies"}
-				})
-
-				// TODO: Cache the motivation as a "Reveal" in std.Path{"Performance", "Epiphanies"}
+				epiphanyReveals.Hit()
 			}
 		}
 	}()
@@ -76,39 +114,356 @@ func (e *Epiphany[TIdealized, TMaterialized]) StringifyFn(fn func() string) {
 	e.thought.StringifyFn(fn)
 }
 
-// Reveal returns the underlying revelation of this Epiphany.
+// SizeFn sets the method for measuring the approximate number of bytes this Epiphany's revelation occupies, which
+// the Revelations manager uses to enforce its Budget.  If nil (default), the revelation's structure is walked instead.
+func (e *Epiphany[TIdealized, TMaterialized]) SizeFn(fn func(TMaterialized) uint) {
+	e.gate.Lock()
+	defer e.gate.Unlock()
+	e.sizeOf = fn
+}
+
+// measure returns the approximate size of the provided revelation.
+func (e *Epiphany[TIdealized, TMaterialized]) measure(revelation TMaterialized) uint {
+	if e.sizeOf != nil {
+		return e.sizeOf(revelation)
+	}
+	return approximateSize(revelation)
+}
+
+// Reveal returns the underlying revelation of this Epiphany, materializing it if necessary.
 //
 // NOTE: To reveal a relative path, please use Recall.
 func (e *Epiphany[TIdealized, TMaterialized]) Reveal(code ...any) (TMaterialized, error) {
+	return e.RevealContext(context.Background(), code...)
+}
+
+// RevealContext returns the underlying revelation of this Epiphany, materializing it if necessary.  If a materialization
+// is already in flight, this waits upon its result rather than starting another.
+//
+// NOTE: The code is checked before anything is handed over - cached, stale, or in-flight alike - so sharing a
+// materialization never shares it with a caller who couldn't have revealed it themselves.
+//
+// NOTE: If the context is done first, this returns its error - but the materialization itself carries on, as other
+// callers may still be waiting upon it.  The 'materialize' function takes no context, so it can't be interrupted
+// anyway - once it finishes, its result becomes the revelation as usual.
+func (e *Epiphany[TIdealized, TMaterialized]) RevealContext(ctx context.Context, code ...any) (TMaterialized, error) {
+	if err := e.mayReveal(code...); err != nil {
+		var zero TMaterialized
+		return zero, err
+	}
+	return e.reveal(ctx)
+}
+
+// reveal returns the underlying revelation of this Epiphany, materializing it if necessary.
+//
+// NOTE: The caller must have already checked their code through mayReveal.
+func (e *Epiphany[TIdealized, TMaterialized]) reveal(ctx context.Context) (TMaterialized, error) {
+	var zero TMaterialized
+
+	e.gate.Lock()
+	e.motivateDecay()
+	if e.revelation != nil {
+		material := *e.revelation
+		Revelations.touch(e, e.size)
+		e.gate.Unlock()
+
+		// NOTE: This happens outside the gate, as enforcing the budget may need to evict other epiphanies
+		Revelations.enforce(e)
+		return material, nil
+	}
+	flight := e.begin()
+	e.gate.Unlock()
+
+	select {
+	case <-flight.done:
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+	if flight.err != nil {
+		return zero, flight.err
+	}
+	return flight.material, nil
+}
+
+// RevealStale returns the underlying revelation of this Epiphany without waiting upon a rematerialization.  If the
+// epiphany was re-described since it last materialized, the previous revelation is returned while the new one
+// materializes in the background - making this ideal for anything revealing once per frame.
+//
+// NOTE: If there's no previous revelation to fall back upon, this waits just like Reveal.
+func (e *Epiphany[TIdealized, TMaterialized]) RevealStale(code ...any) (TMaterialized, error) {
+	if err := e.mayReveal(code...); err != nil {
+		var zero TMaterialized
+		return zero, err
+	}
+
+	e.gate.Lock()
+	e.motivateDecay()
+	if e.revelation == nil && e.stale != nil {
+		e.begin()
+		material := *e.stale
+		e.gate.Unlock()
+		return material, nil
+	}
+	e.gate.Unlock()
+
+	return e.reveal(context.Background())
+}
+
+// Prewarm begins materializing this Epiphany in the background, so a later Reveal needn't wait as long.
+//
+// NOTE: The code is checked just like Reveal, and nothing is materialized if it's invalid.  Any error from the
+// materialization itself is surfaced by the next Reveal, which will attempt to materialize again.
+func (e *Epiphany[TIdealized, TMaterialized]) Prewarm(code ...any) error {
+	if err := e.mayReveal(code...); err != nil {
+		return err
+	}
+
 	e.gate.Lock()
 	defer e.gate.Unlock()
 
+	if e.revelation == nil {
+		e.begin()
+	}
+	return nil
+}
+
+// mayReveal returns the error revealing the underlying Thought with the provided code would yield, auditing the attempt.
+func (e *Epiphany[TIdealized, TMaterialized]) mayReveal(code ...any) error {
+	return e.thought.revealWith(nil, func() {}, code...)
+}
+
+// motivateDecay restarts the decay timer.
+//
+// NOTE: This must be called while holding the epiphany's gate.
+func (e *Epiphany[TIdealized, TMaterialized]) motivateDecay() {
 	select {
 	case e.motivate <- nil:
 	default:
 		// No reason to block - contention is "good" for an epiphany
 	}
-	if e.revelation == nil {
-		var ideal TIdealized
-		var material TMaterialized
-		var err error
-		ideal, err = e.thought.Reveal(code)
-		if err != nil {
-			return material, err
+}
+
+// begin returns the in-flight materialization, starting one if necessary.  Once complete, its result becomes the
+// revelation - unless the epiphany was described or revised in the meantime.
+//
+// NOTE: This must be called while holding the epiphany's gate, and only on behalf of a caller who passed mayReveal -
+// the materialization reads the underlying Thought without a code, as it's shared by everyone waiting upon it.
+func (e *Epiphany[TIdealized, TMaterialized]) begin() *materialization[TMaterialized] {
+	if e.inflight != nil {
+		return e.inflight
+	}
+	flight := &materialization[TMaterialized]{done: make(chan struct{})}
+	e.inflight = flight
+	generation := e.generation
+
+	go func() {
+		start := time.Now()
+		flight.material, flight.err = e.materialize(e.thought.current())
+
+		e.gate.Lock()
+		if e.inflight == flight {
+			e.inflight = nil
 		}
+		current := flight.err == nil && e.generation == generation
+		if current {
+			material := flight.material
+			flight.size = e.measure(material)
+			e.revelation = &material
+			e.stale = nil
+			e.size = flight.size
+			e.motivateDecay()
+			Revelations.touch(e, flight.size)
+			epiphanyMaterializations.Hit(time.Since(start))
+		}
+		e.gate.Unlock()
+		close(flight.done)
 
-		material, err = e.materialize(ideal)
-		if err != nil {
-			return material, err
+		if current {
+			Revelations.enforce(e)
 		}
-		e.revelation = &material
-	}
-	return *e.revelation, nil
+	}()
+	return flight
 }
 
 // Describe sets the underlying revelation of this Epiphany.
+//
+// NOTE: Any current revelation - including uncommitted revisions - is discarded, so the next Reveal materializes the
+// new description.  Until then, RevealStale will continue to return the discarded revelation.
 func (e *Epiphany[TIdealized, TMaterialized]) Describe(revelation TIdealized, code ...any) error {
-	return e.thought.Describe(revelation, code...)
+	if err := e.thought.Describe(revelation, code...); err != nil {
+		return err
+	}
+
+	e.gate.Lock()
+	if e.revelation != nil {
+		e.stale = e.revelation
+	}
+	e.revelation = nil
+	e.inflight = nil
+	e.generation++
+	e.dirty = false
+	e.dirtyCode = nil
+	Revelations.forget(e)
+	e.gate.Unlock()
+	return nil
+}
+
+// Revise replaces the materialized revelation of this Epiphany and marks it as dirty, meaning it will be idealized
+// and written back to the underlying Thought when it decays or is explicitly Commit'd.
+//
+// NOTE: The code is checked now and retained for the eventual write back - if it's invalid, this returns
+// errs.InvalidCode without revising anything.  This will return errs.Irreversible if the epiphany was created without
+// an 'idealize' function.
+func (e *Epiphany[TIdealized, TMaterialized]) Revise(revelation TMaterialized, code ...any) error {
+	if err := e.mayDescribe(code...); err != nil {
+		return err
+	}
+
+	e.gate.Lock()
+	e.revise(revelation, code...)
+	e.gate.Unlock()
+
+	Revelations.enforce(e)
+	return nil
+}
+
+// revise replaces the materialized revelation and marks it as dirty, retaining the code for the eventual write back.
+//
+// NOTE: This must be called while holding the epiphany's gate, after the code has passed mayDescribe.
+func (e *Epiphany[TIdealized, TMaterialized]) revise(revelation TMaterialized, code ...any) {
+	e.revelation = &revelation
+	e.stale = nil
+	e.inflight = nil
+	e.generation++
+	e.size = e.measure(revelation)
+	e.dirty = true
+	e.dirtyCode = code
+	e.motivateDecay()
+	Revelations.touch(e, e.size)
+}
+
+// update reveals, modifies, and revises this Epiphany as a single step, holding its gate from the moment the
+// revelation is read until it's revised - see Thought.update.
+//
+// NOTE: Materialization can't happen while the gate is held, so if the revelation decays (or is re-described) before
+// the gate is acquired, this simply materializes again.
+func (e *Epiphany[TIdealized, TMaterialized]) update(fn func(*TMaterialized) error, code ...any) error {
+	if err := e.mayDescribe(code...); err != nil {
+		return err
+	}
+
+	for {
+		if _, err := e.reveal(context.Background()); err != nil {
+			return err
+		}
+
+		e.gate.Lock()
+		if e.revelation == nil {
+			e.gate.Unlock()
+			continue
+		}
+		revelation := *e.revelation
+		err := fn(&revelation)
+		if err == nil {
+			e.revise(revelation, code...)
+		}
+		e.gate.Unlock()
+
+		if err == nil {
+			Revelations.enforce(e)
+		}
+		return err
+	}
+}
+
+// MarkDirty flags that the current revelation has been mutated in place (such as drawing upon a revealed image), meaning
+// it will be idealized and written back to the underlying Thought when it decays or is explicitly Commit'd.
+//
+// NOTE: The code is checked now and retained for the eventual write back - if it's invalid, this returns
+// errs.InvalidCode without marking anything.  This will return errs.Irreversible if the epiphany was created without
+// an 'idealize' function.
+func (e *Epiphany[TIdealized, TMaterialized]) MarkDirty(code ...any) error {
+	if err := e.mayDescribe(code...); err != nil {
+		return err
+	}
+
+	e.gate.Lock()
+	defer e.gate.Unlock()
+	if e.revelation != nil {
+		e.dirty = true
+		e.dirtyCode = code
+	}
+	return nil
+}
+
+// Commit immediately idealizes a dirty revelation and writes it back to the underlying Thought.  If no code is
+// provided, the code given when the revelation was dirtied is used.
+//
+// NOTE: A clean revelation has nothing to commit, so this simply returns nil.
+func (e *Epiphany[TIdealized, TMaterialized]) Commit(code ...any) error {
+	e.gate.Lock()
+	defer e.gate.Unlock()
+
+	if len(code) > 0 {
+		e.dirtyCode = code
+	}
+	return e.commit()
+}
+
+// commit writes a dirty revelation back to the underlying Thought.
+//
+// NOTE: This must be called while holding the epiphany's gate.
+func (e *Epiphany[TIdealized, TMaterialized]) commit() error {
+	if !e.dirty || e.revelation == nil {
+		return nil
+	}
+	if e.idealize == nil {
+		return errs.Irreversible
+	}
+
+	ideal, err := e.idealize(*e.revelation)
+	if err != nil {
+		return err
+	}
+	if err = e.thought.Describe(ideal, e.dirtyCode...); err != nil {
+		return err
+	}
+	e.dirty = false
+	e.dirtyCode = nil
+	return nil
+}
+
+// decompose clears the revelation out of memory, writing it back to the underlying Thought first if it's dirty.
+//
+// NOTE: If the write back fails, the revelation remains materialized rather than losing its changes - the error is
+// attached to a hit in std.Path{"Performance", "Epiphanies", "DecayFailure"}, and the next Commit will try again.
+func (e *Epiphany[TIdealized, TMaterialized]) decompose() {
+	e.gate.Lock()
+	defer e.gate.Unlock()
+
+	if err := e.commit(); err != nil {
+		epiphanyDecayFailures.Hit(err)
+		return
+	}
+	e.revelation = nil
+	Revelations.forget(e)
+	epiphanyDecays.Hit()
+}
+
+// evict satisfies the Revelations manager by decomposing early - unless the epiphany is currently busy, or its
+// revisions can't be committed, in which case it yields false.
+func (e *Epiphany[TIdealized, TMaterialized]) evict() bool {
+	if !e.gate.TryLock() {
+		return false
+	}
+	defer e.gate.Unlock()
+
+	if err := e.commit(); err != nil {
+		return false
+	}
+	e.revelation = nil
+	Revelations.forget(e)
+	return true
 }
 
 // Recall walks the provided Path relative to the current Epiphany and yields the result.
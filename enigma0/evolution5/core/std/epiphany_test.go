@@ -0,0 +1,190 @@
+package std
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+// numeral creates a bidirectional Epiphany which materializes an integer into its decimal string, constrained
+// by the provided disclosure.
+func numeral(ideal int, decay time.Duration, disclosure *Disclosure) *Epiphany[int, string] {
+	epi := NewEpiphany(func(ideal int) (string, error) {
+		return strconv.Itoa(ideal), nil
+	}, decay, func(material string) (int, error) {
+		return strconv.Atoi(material)
+	})
+	epi.Describe(ideal)
+	epi.thought.disclosure = disclosure
+	return epi
+}
+
+func TestEpiphanyReviseChecksCode(t *testing.T) {
+	epi := numeral(42, time.Hour, &Disclosure{Constraint: relationally.Inclusive, code: "secret"})
+	if material, err := epi.Reveal(); err != nil || material != "42" {
+		t.Fatalf("revealed %q (%v), want \"42\"", material, err)
+	}
+
+	if err := epi.Revise("7", "wrong"); !errors.Is(err, errs.InvalidCode) {
+		t.Fatalf("revised with the wrong code and got %v, want errs.InvalidCode", err)
+	}
+	if err := epi.MarkDirty("wrong"); !errors.Is(err, errs.InvalidCode) {
+		t.Fatalf("marked dirty with the wrong code and got %v, want errs.InvalidCode", err)
+	}
+	if material, _ := epi.Reveal(); material != "42" || epi.dirty {
+		t.Fatalf("revealed %q (dirty: %v) after rejected revisions, want a clean \"42\"", material, epi.dirty)
+	}
+
+	if err := epi.Revise("7", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := epi.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if ideal, _ := epi.thought.Reveal(); ideal != 7 {
+		t.Errorf("committed %d, want 7", ideal)
+	}
+}
+
+func TestEpiphanyIrreversible(t *testing.T) {
+	epi := NewEpiphany(func(ideal int) (string, error) {
+		return strconv.Itoa(ideal), nil
+	}, time.Hour)
+	if err := epi.Revise("7"); !errors.Is(err, errs.Irreversible) {
+		t.Errorf("revised a one way epiphany and got %v, want errs.Irreversible", err)
+	}
+	if err := epi.MarkDirty(); !errors.Is(err, errs.Irreversible) {
+		t.Errorf("marked a one way epiphany dirty and got %v, want errs.Irreversible", err)
+	}
+}
+
+func TestEpiphanyDecayWritesBack(t *testing.T) {
+	epi := numeral(42, 10*time.Millisecond, &Disclosure{Constraint: relationally.Open})
+	if err := epi.Revise("9"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Revise's motivation is dropped if the decay loop isn't listening yet, so this waits for it to start the timer
+	epi.motivate <- nil
+
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		ideal, _ := epi.thought.Reveal()
+		epi.gate.Lock()
+		decayed := epi.revelation == nil
+		epi.gate.Unlock()
+		if ideal == 9 && decayed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the revision to be written back on decay, have %d", ideal)
+		}
+	}
+}
+
+func TestEpiphanyDecayFailure(t *testing.T) {
+	failure := errors.New("unidealizable")
+	epi := NewEpiphany(func(ideal int) (string, error) {
+		return strconv.Itoa(ideal), nil
+	}, time.Hour, func(material string) (int, error) {
+		return 0, failure
+	})
+	if err := epi.Revise("7"); err != nil {
+		t.Fatal(err)
+	}
+
+	failures := epiphanyDecayFailures.Count()
+	epi.decompose()
+	if got := epiphanyDecayFailures.Count(); got != failures+1 {
+		t.Errorf("counted %d decay failures, want %d", got, failures+1)
+	}
+	if material, _ := epi.Reveal(); material != "7" {
+		t.Errorf("revealed %q after a failed decay, want the retained \"7\"", material)
+	}
+	if err := epi.Commit(); !errors.Is(err, failure) {
+		t.Errorf("committed and got %v, want the idealize error", err)
+	}
+}
+
+// gated creates an Exclusive Epiphany whose materializations each wait upon the returned channel before completing.
+func gated(ideal int) (*Epiphany[int, string], chan struct{}) {
+	release := make(chan struct{})
+	epi := NewEpiphany(func(ideal int) (string, error) {
+		<-release
+		return strconv.Itoa(ideal), nil
+	}, time.Hour)
+	epi.Describe(ideal)
+	epi.thought.disclosure = &Disclosure{Constraint: relationally.Exclusive, code: "secret"}
+	return epi, release
+}
+
+func TestEpiphanyRevealChecksEveryCaller(t *testing.T) {
+	epi, release := gated(42)
+
+	// Nothing is materialized on behalf of an invalid code
+	if err := epi.Prewarm("wrong"); !errors.Is(err, errs.InvalidCode) {
+		t.Fatalf("prewarmed with the wrong code and got %v, want errs.InvalidCode", err)
+	}
+	epi.gate.Lock()
+	started := epi.inflight != nil
+	epi.gate.Unlock()
+	if started {
+		t.Fatal("expected no materialization to begin for the wrong code")
+	}
+
+	// An in-flight materialization is only shared with callers who may reveal it
+	if err := epi.Prewarm("secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := epi.Reveal("wrong"); !errors.Is(err, errs.InvalidCode) {
+		t.Fatalf("joined the materialization with the wrong code and got %v, want errs.InvalidCode", err)
+	}
+	joined := make(chan string)
+	go func() {
+		material, _ := epi.Reveal("secret")
+		joined <- material
+	}()
+	close(release)
+	if material := <-joined; material != "42" {
+		t.Fatalf("joined the materialization and revealed %q, want \"42\"", material)
+	}
+
+	// As is a cached revelation
+	if _, err := epi.Reveal("wrong"); !errors.Is(err, errs.InvalidCode) {
+		t.Fatalf("revealed a cached revelation with the wrong code and got %v, want errs.InvalidCode", err)
+	}
+
+	// And a stale one
+	if err := epi.Describe(7, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := epi.RevealStale("wrong"); !errors.Is(err, errs.InvalidCode) {
+		t.Fatalf("revealed a stale revelation with the wrong code and got %v, want errs.InvalidCode", err)
+	}
+	if material, err := epi.RevealStale("secret"); err != nil || material != "42" {
+		t.Fatalf("revealed %q (%v) while rematerializing, want the stale \"42\"", material, err)
+	}
+}
+
+func TestEpiphanyRevealContext(t *testing.T) {
+	epi, release := gated(42)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := epi.RevealContext(ctx, "secret"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("revealed with a cancelled context and got %v, want context.Canceled", err)
+	}
+
+	// The abandoned materialization carries on, and its result becomes the revelation
+	close(release)
+	if material, err := epi.Reveal("secret"); err != nil || material != "42" {
+		t.Fatalf("revealed %q (%v) after abandoning the materialization, want \"42\"", material, err)
+	}
+	if count := Revelations.Count(); count == 0 {
+		t.Error("expected the finished materialization to be tracked")
+	}
+}
@@ -6,6 +6,12 @@ import (
 	"time"
 )
 
+// These statistics are shared by every Gate - see Performance.
+var (
+	gateContention = Performance("Gates", "Contention")
+	gateTimeouts   = Performance("Gates", "Timeout")
+)
+
 // A Gate can patiently Attempt to interface with a sync.Mutex.
 type Gate struct {
 	sync.Mutex
@@ -17,8 +23,18 @@ type Gate struct {
 // NOTE: This will attempt 111 locks (completely arbitrary) before dropping to "lock polling", which starts at
 // 1µs and exponentially decays the polling rate of every cycle up to a maximum of 11ms.  Theoretically, this should
 // cause just over two hundred lock attempts in the first second before settling in at roughly ninety a second.
+//
+// NOTE: Every contended attempt records how long it waited into std.Path{"Performance", "Gates", "Contention"}, and
+// every concession is recorded into std.Path{"Performance", "Gates", "Timeout"}.
 func (g *Gate) Attempt(timeout time.Duration) bool {
 	start := time.Now()
+	if g.TryLock() {
+		return true
+	}
+	defer func() {
+		gateContention.Hit(time.Since(start))
+	}()
+
 	for i := 0; i < 111; i++ {
 		if g.TryLock() {
 			return true
@@ -28,6 +44,7 @@ func (g *Gate) Attempt(timeout time.Duration) bool {
 			runtime.Gosched()
 		}
 		if time.Since(start) >= timeout {
+			gateTimeouts.Hit()
 			return false
 		}
 	}
@@ -37,6 +54,7 @@ func (g *Gate) Attempt(timeout time.Duration) bool {
 
 	for {
 		if time.Since(start) >= timeout {
+			gateTimeouts.Hit()
 			return false
 		}
 
@@ -1,6 +1,7 @@
 package std
 
 import (
+	"slices"
 	"time"
 
 	"git.ignitelabs.net/janos/core/std"
@@ -45,3 +46,58 @@ type Impulse struct {
 	// NOTE: The impulse is not added to the buffer before calling the potential or action.
 	Timeline *std.TemporalBuffer[Impulse]
 }
+
+// Measure records this impulse's timing into the performance registry beneath std.Path{"Performance", "Impulses", Bridge...}
+//
+// - CyclePeriod: the time between the prior impulse's inception and this impulse's inception
+//
+// - RefractoryPeriod: the time between the prior impulse's completion and this impulse's inception
+//
+// - ResponseTime: the time between this impulse's inception and activation
+//
+// - RunTime: the time between this impulse's activation and completion
+//
+// NOTE: The prior impulse is the latest found in the Timeline, and any period which can't yet be measured is skipped.
+//
+// NOTE: Record calls this before adding the impulse to its Timeline, so you only need to call it yourself when
+// recording the impulse some other way.
+func (imp *Impulse) Measure() {
+	measure := func(name string, period time.Duration) {
+		steps := append(append(Path{"Impulses"}, slices.Clone(imp.Bridge)...), name)
+		Performance(steps...).Hit(period)
+	}
+
+	if imp.Timeline != nil {
+		if prior := imp.Timeline.Latest(); len(prior) > 0 {
+			measure("CyclePeriod", imp.Inception.Sub(prior[0].Element.Inception))
+			if prior[0].Element.Completed != nil {
+				measure("RefractoryPeriod", imp.Inception.Sub(*prior[0].Element.Completed))
+			}
+		}
+	}
+	if imp.Activated != nil {
+		measure("ResponseTime", imp.Activated.Sub(imp.Inception))
+		if imp.Completed != nil {
+			measure("RunTime", imp.Completed.Sub(*imp.Activated))
+		}
+	}
+}
+
+// Record adds this impulse to its Timeline at its Inception and reports the activation into the performance registry
+// beneath std.Path{"Performance", "Synapses", Bridge...} - attaching its run time, if it has completed.  Its timing is
+// reported through Measure first, while the Timeline's latest entry is still the prior impulse.
+//
+// NOTE: Synapses live in janos/core, so whatever fires an impulse should call Record once the action finishes.
+func (imp *Impulse) Record() {
+	steps := append(Path{"Synapses"}, slices.Clone(imp.Bridge)...)
+	if imp.Activated != nil && imp.Completed != nil {
+		Performance(steps...).Hit(imp.Completed.Sub(*imp.Activated))
+	} else {
+		Performance(steps...).Hit()
+	}
+
+	imp.Measure()
+	if imp.Timeline != nil {
+		imp.Timeline.Record(imp.Inception, *imp)
+	}
+}
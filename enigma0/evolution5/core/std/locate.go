@@ -1,17 +1,357 @@
 package std
 
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+)
+
+// A PathError describes the step of a Path which could not be walked.
+//
+// NOTE: This satisfies errors.Is for errs.InvalidPath, as well as for any underlying cause (such as errs.InvalidCode).
+type PathError struct {
+	// Index holds the position of the failing step within the path.
+	Index int
+
+	// Step holds the failing step itself.
+	Step any
+
+	// Reason describes why the step could not be walked.
+	Reason string
+
+	// Cause holds the underlying error, if one was encountered while walking the step.
+	Cause error
+}
+
+func (e *PathError) Error() string {
+	msg := fmt.Sprintf("%v at step %d (%v) - %s", errs.InvalidPath, e.Index, e.Step, e.Reason)
+	if e.Cause != nil {
+		msg += ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+func (e *PathError) Unwrap() []error {
+	if e.Cause != nil {
+		return []error{errs.InvalidPath, e.Cause}
+	}
+	return []error{errs.InvalidPath}
+}
+
+// A revealer is anything which can be revealed with a code - such as a Thought or Epiphany.
+type revealer interface {
+	revealAny(code ...any) (any, error)
+}
+
+func (t *Thought[T]) revealAny(code ...any) (any, error) {
+	return t.Reveal(code...)
+}
+
+func (e *Epiphany[TIdealized, TMaterialized]) revealAny(code ...any) (any, error) {
+	return e.Reveal(code...)
+}
+
+// Locate walks the provided Path relative to the source and yields the result.  Each step is either a Step, or
+// directly the Stringable data of a step with no code.  At every step, the source is addressed by its type:
+//
+// - Maps treat the step as a key
+//
+// - Slices and arrays treat the step as an index, parsed from its string form
+//
+// - Structs treat the step as the name of an exported field or method - methods must take no arguments and may
+// optionally yield an error alongside their result
+//
+// - Pure functions (no arguments or results) are called if the step is empty
+//
+// - Providing functions (no arguments, a result, and an optional error) are called, and their result is what the step addresses
+//
+// - Thoughts and Epiphanies are revealed using the step's code, and their revelation is what the step addresses
+//
+// - Channels receive the step's data, unless they are receive-only
+//
+// NOTE: Sending never blocks - a channel which is closed, nil, or can't accept the data right away (such as an
+// unbuffered channel nobody is receiving from) fails the step instead.
+//
+// NOTE: Pointers and interfaces are walked through transparently.  Rather than panicking, any step which can't be
+// walked yields a *PathError satisfying errs.InvalidPath.
 func Locate(source any, relative Path) (any, error) {
-	// 0. Pop the first step in the path
-	// 1 Find the type of the source
-	// 1.0 If a map, treat the step as a key
-	// 1.1 If a slice or array, treat the step as an index accessor - parseable from a string
-	// 1.2 If an object, treat the step as a Stringable field or method accessor
-	// 1.3 If a pure function, call it if the step is empty - otherwise, panic (we can't dig deeper into that)
-	// 1.4 If a providing function, call it and then treat the result as what this step should be addressing
-	// 1.5 If another thought, reveal it using the optional code in the current step and treat the result as what this step should be addressing
-	// 1.6 If it's a bidirectional or send-only channel, send the step into it - if receive-only, panic
-	// 1.7 Otherwise, panic since we cannot evaluate INTO any other type - only reveal it
-	// 2. Recursively call this method on the result with the current step removed in the path
-
-	panic("not implemented")
+	current := reflect.ValueOf(source)
+	for i := 0; i < len(relative); {
+		data, code := stepOf(relative[i])
+		fail := func(reason string, cause ...error) (any, error) {
+			err := &PathError{Index: i, Step: relative[i], Reason: reason}
+			if len(cause) > 0 {
+				err.Cause = cause[0]
+			}
+			return nil, err
+		}
+
+		for current.IsValid() && current.Kind() == reflect.Interface && !current.IsNil() {
+			current = current.Elem()
+		}
+		if !current.IsValid() {
+			return fail("there is nothing to walk into")
+		}
+
+		// Thoughts are revealed, and the revelation is what the step addresses
+		if current.CanInterface() {
+			if r, ok := current.Interface().(revealer); ok && !current.IsNil() {
+				revealed, err := r.revealAny(codesOf(code)...)
+				if err != nil {
+					return fail("the thought could not be revealed", err)
+				}
+				current = reflect.ValueOf(revealed)
+				continue
+			}
+		}
+
+		switch current.Kind() {
+		case reflect.Pointer:
+			if current.IsNil() {
+				return fail("the pointer is nil")
+			}
+			if method, ok := methodOf(current, data); ok {
+				result, err := callMethod(method)
+				if err != nil {
+					return fail("the method could not be called", err)
+				}
+				current = result
+				i++
+				continue
+			}
+			current = current.Elem()
+		case reflect.Struct:
+			if method, ok := methodOf(current, data); ok {
+				result, err := callMethod(method)
+				if err != nil {
+					return fail("the method could not be called", err)
+				}
+				current = result
+				i++
+				continue
+			}
+			name, ok := stringOf(data)
+			if !ok {
+				return fail("a field name must be Stringable")
+			}
+			field, found := current.Type().FieldByName(name)
+			if !found || !field.IsExported() {
+				return fail(fmt.Sprintf("%v has no exported field or method named '%s'", current.Type(), name))
+			}
+			value, err := current.FieldByIndexErr(field.Index)
+			if err != nil {
+				return fail(fmt.Sprintf("the field '%s' is promoted through a nil embedded pointer", name), err)
+			}
+			current = value
+			i++
+		case reflect.Map:
+			if current.IsNil() {
+				return fail("the map is nil")
+			}
+			key, ok := convertStep(data, current.Type().Key())
+			if !ok {
+				return fail(fmt.Sprintf("the step is not a valid %v key", current.Type().Key()))
+			}
+			value := current.MapIndex(key)
+			if !value.IsValid() {
+				return fail("the map has no such key")
+			}
+			current = value
+			i++
+		case reflect.Slice, reflect.Array:
+			index, ok := indexOf(data)
+			if !ok {
+				return fail("the step is not a valid index")
+			}
+			if index >= current.Len() {
+				return fail(fmt.Sprintf("the index is out of range [0:%d]", current.Len()))
+			}
+			current = current.Index(index)
+			i++
+		case reflect.Func:
+			if current.IsNil() {
+				return fail("the function is nil")
+			}
+			t := current.Type()
+			if t.NumIn() > 0 {
+				return fail("functions requiring arguments can't be walked")
+			}
+			if t.NumOut() == 0 {
+				if s, ok := stringOf(data); !ok || s != "" {
+					return fail("a pure function can't be walked into - only called with an empty step")
+				}
+				current.Call(nil)
+				current = reflect.Value{}
+				i++
+				continue
+			}
+			result, err := callMethod(current)
+			if err != nil {
+				return fail("the function could not be called", err)
+			}
+			current = result
+		case reflect.Chan:
+			if current.Type().ChanDir() == reflect.RecvDir {
+				return fail("a receive-only channel can't be sent to")
+			}
+			value, ok := convertStep(data, current.Type().Elem())
+			if !ok {
+				return fail(fmt.Sprintf("the step can't be sent into a channel of %v", current.Type().Elem()))
+			}
+			if sent, closed := trySend(current, value); closed {
+				return fail("the channel is closed")
+			} else if !sent {
+				return fail("the channel isn't ready to receive")
+			}
+			current = reflect.Value{}
+			i++
+		default:
+			return fail(fmt.Sprintf("a %v can't be walked into", current.Type()))
+		}
+	}
+
+	if !current.IsValid() || !current.CanInterface() {
+		return nil, nil
+	}
+	return current.Interface(), nil
+}
+
+// stepOf separates the data and code of a path step.
+func stepOf(step any) (data any, code any) {
+	switch typed := step.(type) {
+	case Step:
+		return typed.Data, typed.Code
+	case *Step:
+		if typed == nil {
+			return nil, nil
+		}
+		return typed.Data, typed.Code
+	default:
+		return step, nil
+	}
+}
+
+// stringOf stringifies the provided step data, if it's Stringable.
+func stringOf(data any) (string, bool) {
+	if !Stringable(data) {
+		return "", false
+	}
+	return Stringify(data), true
+}
+
+// indexOf parses the provided step data into a non-negative index.
+func indexOf(data any) (int, bool) {
+	s, ok := stringOf(data)
+	if !ok {
+		return 0, false
+	}
+	index, err := strconv.Atoi(s)
+	if err != nil || index < 0 {
+		return 0, false
+	}
+	return index, true
+}
+
+// methodOf finds the method named by the provided step data, if the value has one.
+func methodOf(v reflect.Value, data any) (reflect.Value, bool) {
+	name, ok := stringOf(data)
+	if !ok || name == "" || v.NumMethod() == 0 {
+		return reflect.Value{}, false
+	}
+	method := v.MethodByName(name)
+	return method, method.IsValid()
+}
+
+// callMethod calls a function which takes no arguments, yielding its result and an optional trailing error.
+func callMethod(fn reflect.Value) (reflect.Value, error) {
+	t := fn.Type()
+	if t.NumIn() > 0 {
+		return reflect.Value{}, fmt.Errorf("%v requires arguments", t)
+	}
+
+	errorType := reflect.TypeFor[error]()
+	switch {
+	case t.NumOut() == 0:
+		fn.Call(nil)
+		return reflect.Value{}, nil
+	case t.NumOut() == 1:
+		return fn.Call(nil)[0], nil
+	case t.NumOut() == 2 && t.Out(1) == errorType:
+		out := fn.Call(nil)
+		if !out[1].IsNil() {
+			return reflect.Value{}, out[1].Interface().(error)
+		}
+		return out[0], nil
+	default:
+		return reflect.Value{}, fmt.Errorf("%v yields too many results", t)
+	}
+}
+
+// convertStep converts the provided step data into a value of the provided type, parsing it from its string form if necessary.
+func convertStep(data any, to reflect.Type) (reflect.Value, bool) {
+	if data == nil {
+		return reflect.Zero(to), true
+	}
+	v := reflect.ValueOf(data)
+	if v.Type().AssignableTo(to) {
+		return v, true
+	}
+
+	s, stringable := stringOf(data)
+	var err error
+	out := reflect.New(to).Elem()
+	switch to.Kind() {
+	case reflect.String:
+		if !stringable {
+			return reflect.Value{}, false
+		}
+		out.SetString(s)
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); stringable && err == nil {
+			out.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		if n, err = strconv.ParseInt(s, 10, to.Bits()); stringable && err == nil {
+			out.SetInt(n)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		if n, err = strconv.ParseUint(s, 10, to.Bits()); stringable && err == nil {
+			out.SetUint(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(s, to.Bits()); stringable && err == nil {
+			out.SetFloat(f)
+		}
+	case reflect.Interface:
+		if v.Type().Implements(to) {
+			out.Set(v)
+			return out, true
+		}
+		return reflect.Value{}, false
+	default:
+		if v.Type().ConvertibleTo(to) {
+			return v.Convert(to), true
+		}
+		return reflect.Value{}, false
+	}
+	if !stringable || err != nil {
+		return reflect.Value{}, false
+	}
+	return out, true
+}
+
+// trySend sends the value into the channel without blocking, reporting whether it was received or the channel was closed.
+func trySend(channel, value reflect.Value) (sent bool, closed bool) {
+	defer func() {
+		if recover() != nil {
+			sent, closed = false, true
+		}
+	}()
+	return channel.TrySend(value), false
 }
@@ -0,0 +1,123 @@
+package std
+
+import (
+	"errors"
+	"testing"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+type located struct {
+	Name  string
+	Items []int
+}
+
+func (l *located) Double() int { return l.Items[0] * 2 }
+
+type Embedded struct {
+	V int
+}
+
+type outer struct {
+	*Embedded
+}
+
+func TestLocate(t *testing.T) {
+	d := &Disclosure{Constraint: relationally.Exclusive}
+	d.Code("secret")
+	thought, _ := NewThought(map[int]string{7: "seven"}, d)
+	source := map[string]any{
+		"struct":   &located{Name: "n", Items: []int{4, 5}},
+		"provider": func() any { return map[string]int{"x": 1} },
+		"thought":  thought,
+		"array":    [2]string{"p", "q"},
+		"embedded": outer{Embedded: &Embedded{V: 3}},
+	}
+
+	tests := []struct {
+		path Path
+		want any
+	}{
+		{Path{"struct", "Name"}, "n"},
+		{Path{"struct", "Items", "1"}, 5},
+		{Path{"struct", "Items", 0}, 4},
+		{Path{"struct", "Double"}, 8},
+		{Path{"provider", "x"}, 1},
+		{Path{"thought", Step{Data: 7, Code: "secret"}}, "seven"},
+		{Path{"array", 1}, "q"},
+		{Path{"embedded", "V"}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path.String(), func(t *testing.T) {
+			got, err := Locate(source, tt.path)
+			if err != nil || got != tt.want {
+				t.Fatalf("Locate = %v, %v - want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocateInvalidPaths(t *testing.T) {
+	d := &Disclosure{Constraint: relationally.Exclusive}
+	d.Code("secret")
+	thought, _ := NewThought(1, d)
+	closed := make(chan int, 1)
+	close(closed)
+	source := map[string]any{
+		"thought":    thought,
+		"slice":      []int{1},
+		"embedded":   outer{},
+		"closed":     closed,
+		"unbuffered": make(chan int),
+		"receiver":   (<-chan int)(make(chan int, 1)),
+	}
+
+	tests := []struct {
+		name  string
+		path  Path
+		index int
+		cause error
+	}{
+		{name: "missing key", path: Path{"missing"}, index: 0},
+		{name: "out of range", path: Path{"slice", 3}, index: 1},
+		{name: "nil pointer", path: Path{"embedded", "Embedded", "V"}, index: 2},
+		{name: "nil embedded pointer", path: Path{"embedded", "V"}, index: 1},
+		{name: "invalid code", path: Path{"thought", Step{Code: "wrong"}}, index: 1, cause: errs.InvalidCode},
+		{name: "closed channel", path: Path{"closed", 5}, index: 1},
+		{name: "unbuffered channel", path: Path{"unbuffered", 5}, index: 1},
+		{name: "receive-only channel", path: Path{"receiver", 5}, index: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Locate(source, tt.path)
+			var pathErr *PathError
+			if !errors.As(err, &pathErr) || !errors.Is(err, errs.InvalidPath) {
+				t.Fatalf("Locate error = %v, want a *PathError", err)
+			}
+			if pathErr.Index != tt.index {
+				t.Fatalf("failed at step %d, want %d", pathErr.Index, tt.index)
+			}
+			if tt.cause != nil && !errors.Is(err, tt.cause) {
+				t.Fatalf("Locate error = %v, want it to wrap %v", err, tt.cause)
+			}
+		})
+	}
+}
+
+func TestLocateSendsIntoChannels(t *testing.T) {
+	buffered := make(chan int, 1)
+	got, err := Locate(map[string]any{"channel": buffered}, Path{"channel", 5})
+	if err != nil || got != nil {
+		t.Fatalf("Locate = %v, %v - want nothing", got, err)
+	}
+	if sent := <-buffered; sent != 5 {
+		t.Fatalf("sent %d, want 5", sent)
+	}
+
+	// The buffer is full again, so a second send can't be received right away
+	buffered <- 1
+	if _, err = Locate(map[string]any{"channel": buffered}, Path{"channel", 5}); !errors.Is(err, errs.InvalidPath) {
+		t.Fatalf("sent into a full channel and got %v, want errs.InvalidPath", err)
+	}
+}
@@ -1,3 +1,4 @@
 package std
 
-var Memory Thought[map[string]any]
+// Memory is a relationally.Open Thought which anything can reveal or describe.
+var Memory, _ = NewThought(make(map[string]any))
@@ -0,0 +1,138 @@
+package std
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// OpenMetricsContentType is the HTTP content type of the OpenMetrics text exposition format.
+const OpenMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// OpenMetricsQuantiles defines which quantiles are exposed for every Statistic with attached durations.
+var OpenMetricsQuantiles = []float64{0.5, 0.9, 0.99}
+
+// OpenMetrics writes every Measurement beneath the provided steps of the performance registry in the OpenMetrics text
+// format.  Each metric is named after its snake_cased Path, and every Statistic exposes -
+//
+// <name>_hits - A gauge of the hits within its window of observance
+//
+// <name>_rate - A gauge of the hits per second within its window of observance
+//
+// <name>_seconds - A summary of the attached durations, if any have been observed
+//
+// For example, std.Path{"Performance", "Impulses", "Server A", "ResponseTime"} is exposed as
+// "performance_impulses_server_a_response_time_seconds"
+//
+// NOTE: Every family of a Statistic is drawn from a single snapshot of its hits, so they always agree with each other.
+//
+// NOTE: Paths which snake_case to the same name - such as "Server A" and "ServerA" - are disambiguated in Path order
+// by suffixing every name after the first with _2, _3, and so on.
+func OpenMetrics(w io.Writer, prefix ...any) error {
+	out := bufio.NewWriter(w)
+	names := make(map[string]struct{})
+	for _, m := range PerformanceSnapshot(prefix...) {
+		name := metricName(m.Path)
+		for i := 2; ; i++ {
+			if _, taken := names[name]; !taken {
+				break
+			}
+			name = fmt.Sprintf("%s_%d", metricName(m.Path), i)
+		}
+		names[name] = struct{}{}
+		help := escapeHelp(m.Path.String())
+
+		observed := m.Statistic.observed()
+		count := uint(len(observed))
+
+		fmt.Fprintf(out, "# TYPE %s_hits gauge\n", name)
+		fmt.Fprintf(out, "# HELP %s_hits Hits observed within the window of %s\n", name, help)
+		fmt.Fprintf(out, "%s_hits %d\n", name, count)
+
+		fmt.Fprintf(out, "# TYPE %s_rate gauge\n", name)
+		fmt.Fprintf(out, "# HELP %s_rate Hits per second observed within the window of %s\n", name, help)
+		fmt.Fprintf(out, "%s_rate %s\n", name, formatFloat(m.Statistic.rateOf(count)))
+
+		durations := durationsOf(observed)
+		if len(durations) == 0 {
+			continue
+		}
+		slices.Sort(durations)
+		var sum time.Duration
+		for _, d := range durations {
+			sum += d
+		}
+		fmt.Fprintf(out, "# TYPE %s_seconds summary\n", name)
+		fmt.Fprintf(out, "# UNIT %s_seconds seconds\n", name)
+		fmt.Fprintf(out, "# HELP %s_seconds Durations observed within the window of %s\n", name, help)
+		for _, q := range OpenMetricsQuantiles {
+			fmt.Fprintf(out, "%s_seconds{quantile=\"%s\"} %s\n", name, formatFloat(q), formatFloat(percentileOf(durations, q*100).Seconds()))
+		}
+		fmt.Fprintf(out, "%s_seconds_sum %s\n", name, formatFloat(sum.Seconds()))
+		fmt.Fprintf(out, "%s_seconds_count %d\n", name, len(durations))
+	}
+	fmt.Fprint(out, "# EOF\n")
+	return out.Flush()
+}
+
+// OpenMetricsHandler creates an http.Handler which serves the OpenMetrics exposition of the provided steps of the
+// performance registry.  This can be wired into a server synapse just like any other handler -
+//
+//	cortex.Synapses() <- neural.Net.Server(lifecycle.Looping, "Metrics", ":4242", func(imp *std.Impulse) http.Handler {
+//		return std.OpenMetricsHandler()
+//	})
+func OpenMetricsHandler(prefix ...any) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", OpenMetricsContentType)
+		if err := OpenMetrics(w, prefix...); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// metricName converts a Path into a snake_cased metric name - for instance, "Epiphanies⇝ResponseTime" becomes "epiphanies_response_time"
+func metricName(path Path) string {
+	var b strings.Builder
+	underscore := func() {
+		if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+			b.WriteRune('_')
+		}
+	}
+
+	for _, step := range StringifyMany(path...) {
+		underscore()
+		runes := []rune(step)
+		for i, r := range runes {
+			switch {
+			case r < unicode.MaxASCII && unicode.IsUpper(r):
+				if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+					(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+					underscore()
+				}
+				b.WriteRune(unicode.ToLower(r))
+			case r < unicode.MaxASCII && (unicode.IsLower(r) || unicode.IsDigit(r)):
+				if b.Len() == 0 && unicode.IsDigit(r) {
+					b.WriteRune('_')
+				}
+				b.WriteRune(r)
+			default:
+				underscore()
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+func escapeHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
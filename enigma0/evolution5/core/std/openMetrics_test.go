@@ -0,0 +1,65 @@
+package std
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricName(t *testing.T) {
+	tests := []struct {
+		path Path
+		want string
+	}{
+		{Path{"Epiphanies", "ResponseTime"}, "epiphanies_response_time"},
+		{Path{"Cortex", "Server A"}, "cortex_server_a"},
+		{Path{"HTTPServer", "9lives"}, "http_server_9lives"},
+		{Path{"9lives"}, "_9lives"},
+		{Path{"Ünïcode", "--Dashes--"}, "n_code_dashes"},
+	}
+	for _, test := range tests {
+		if got := metricName(test.path); got != test.want {
+			t.Errorf("metricName(%v) = %q, want %q", test.path, got, test.want)
+		}
+	}
+}
+
+func TestOpenMetrics(t *testing.T) {
+	for _, prefix := range []Path{{"Exposition"}, {"Impulses", "Exposition"}, {"Synapses", "Exposition"}} {
+		forgetPerformance(prefix...)
+	}
+	inception := time.Now()
+	activated := inception.Add(time.Millisecond)
+	completed := activated.Add(time.Millisecond)
+	imp := &Impulse{Bridge: Path{"Exposition", "Server A"}, Inception: inception, Activated: &activated, Completed: &completed}
+	imp.Record()
+
+	// These snake_case to the same name, so the later path (in Path order) is suffixed
+	Performance("Exposition", "Collide", "Server A").Hit()
+	Performance("Exposition", "Collide", "ServerA").Hit(time.Second)
+
+	var exposition string
+	for _, prefix := range []Path{{"Exposition"}, {"Impulses", "Exposition"}, {"Synapses", "Exposition"}} {
+		var sb strings.Builder
+		if err := OpenMetrics(&sb, prefix...); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasSuffix(sb.String(), "# EOF\n") {
+			t.Errorf("expected the exposition of %v to end with # EOF", prefix)
+		}
+		exposition += sb.String()
+	}
+
+	for _, want := range []string{
+		"performance_synapses_exposition_server_a_hits 1\n",
+		"performance_synapses_exposition_server_a_seconds_count 1\n",
+		"performance_impulses_exposition_server_a_response_time_seconds_count 1\n",
+		"performance_impulses_exposition_server_a_run_time_seconds{quantile=\"0.5\"} 0.001\n",
+		"performance_exposition_collide_server_a_hits 1\n",
+		"performance_exposition_collide_server_a_2_seconds_sum 1\n",
+	} {
+		if !strings.Contains(exposition, want) {
+			t.Errorf("expected the exposition to contain %q, got\n%s", want, exposition)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package parsel
+
+import (
+	"cmp"
+	"math"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/boundary"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/std"
+)
+
+// Bounded is satisfied by cursors which can be measured and steered by the evaluator, such as std.SliceCursor.
+//
+// NOTE: A std.Cursorable which doesn't satisfy Bounded keeps its own boundaries regardless of the expression's
+// brackets, and can't evaluate open-ended ranges or '=' ranges mixing relative and absolute positions.
+type Bounded interface {
+	// Len returns the number of elements the cursor traverses.
+	Len() int
+
+	// Position returns the cursor's current position, once its enqueued movements have been yielded.
+	Position() int
+
+	// Boundary sets and/or gets how subsequently enqueued movements treat the cursor's boundaries.
+	Boundary(mode ...boundary.Mode) boundary.Mode
+}
+
+// Evaluate parses the provided source and evaluates it against the provided slice - see EvaluateSlice.
+func Evaluate[T any](source string, data []T, variables ...map[string]any) ([]T, error) {
+	expr, err := Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	return EvaluateSlice(expr, data, variables...)
+}
+
+// EvaluateSlice evaluates the expression against the provided slice, yielding every element the cursor visits in order.
+//
+// NOTE: Just like index access, square brackets will panic if the cursor moves outside the slice's boundaries.
+func EvaluateSlice[T any](expr *Expression, data []T, variables ...map[string]any) ([]T, error) {
+	return EvaluateCursor[T](expr, std.NewSliceCursor(data, boundary.Panic), variables...)
+}
+
+// EvaluateCursor evaluates the expression against the provided cursor, yielding every element it visits in order.  Any
+// variables referenced by the expression are looked up in the provided maps, in order, and must be integers or
+// functions providing them.
+//
+// NOTE: The cursor is yielded after every operation, and if it satisfies Bounded its boundary is steered by each bracket.
+func EvaluateCursor[T any](expr *Expression, cursor std.Cursorable[T], variables ...map[string]any) ([]T, error) {
+	e := evaluation[T]{
+		cursor:    cursor,
+		variables: variables,
+	}
+	e.bounded, _ = cursor.(Bounded)
+
+	var out []T
+	for _, op := range expr.Operations {
+		elements, err := e.operation(op)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, elements...)
+	}
+	return out, nil
+}
+
+// An evaluation carries the state of a single call to EvaluateCursor.
+type evaluation[T any] struct {
+	cursor    std.Cursorable[T]
+	bounded   Bounded
+	variables []map[string]any
+}
+
+func (e evaluation[T]) operation(op Operation) ([]T, error) {
+	var stride any = 1
+	if op.Stride != nil {
+		var err error
+		if stride, err = e.value(op.Stride); err != nil {
+			return nil, err
+		}
+	}
+
+	switch op.Span {
+	case Inclusive:
+		return e.inclusive(op, stride)
+	case Exclusive:
+		return e.exclusive(op, stride)
+	}
+
+	target, err := e.value(op.From)
+	if err != nil {
+		return nil, err
+	}
+	e.steer(op.Open.Boundary)
+	relative := op.Open.Relative || op.From.Relative
+	switch {
+	case op.Stride == nil && relative:
+		e.cursor.Jump(target)
+	case op.Stride == nil:
+		e.cursor.JumpTo(target)
+	case relative:
+		e.cursor.Walk(target, stride)
+	default:
+		e.cursor.WalkTo(target, stride)
+	}
+	return e.cursor.Yield(), nil
+}
+
+// inclusive jumps to the first position of a ':' range, then walks to the second.
+func (e evaluation[T]) inclusive(op Operation, stride any) ([]T, error) {
+	out, err := e.jumpFrom(op, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	e.steer(op.Close.Boundary)
+	if op.To == nil {
+		end, err := e.end(op)
+		if err != nil {
+			return nil, err
+		}
+		if e.bounded == nil || e.bounded.Position() != end {
+			e.cursor.WalkTo(end, stride)
+		}
+		return append(out, e.cursor.Yield()...), nil
+	}
+
+	target, err := e.value(op.To)
+	if err != nil {
+		return nil, err
+	}
+	relative := op.Close.Relative || op.To.Relative
+	if relative {
+		if distance, ok := std.IntegerOf(target); !ok || distance != 0 {
+			e.cursor.Walk(target, stride)
+		}
+	} else if !e.settled(target, op.Close.Boundary) {
+		e.cursor.WalkTo(target, stride)
+	}
+	return append(out, e.cursor.Yield()...), nil
+}
+
+// exclusive walks between the positions of a '=' range "the long way 'round," excluding both.
+func (e evaluation[T]) exclusive(op Operation, stride any) ([]T, error) {
+	if op.Close.Boundary == boundary.Panic {
+		panic(errorf(op.Offset, "a panicking cursor can't take the long way 'round, as infinity is undefined"))
+	}
+	s, ok := std.IntegerOf(stride)
+	if !ok {
+		return nil, errorf(op.Stride.Offset, "the stride must be an integer")
+	}
+	if s < 0 {
+		s = -s
+	}
+
+	from, to := 0, 0
+	var err error
+	if op.From != nil {
+		if from, err = e.integer(op.From); err != nil {
+			return nil, err
+		}
+	}
+	fromRelative := op.Open.Relative || (op.From != nil && op.From.Relative)
+	toRelative := op.Close.Relative || (op.To != nil && op.To.Relative)
+	if op.To != nil {
+		if to, err = e.integer(op.To); err != nil {
+			return nil, err
+		}
+	} else if to, err = e.end(op); err != nil {
+		return nil, err
+	}
+
+	// The direct path travels in this direction, so the long way 'round begins by stepping in the opposite one
+	direction := cmp.Compare(to, 0)
+	if !toRelative {
+		absolute := from
+		if fromRelative {
+			if e.bounded == nil {
+				return nil, errorf(op.Offset, "mixing a relative and absolute '=' range requires a cursor which satisfies parsel.Bounded")
+			}
+			absolute += e.bounded.Position()
+		}
+		direction = cmp.Compare(to, absolute)
+	}
+	if direction == 0 {
+		direction = 1
+	}
+
+	e.steer(op.Open.Boundary)
+	if fromRelative {
+		e.cursor.Jump(from - direction)
+	} else {
+		e.cursor.JumpTo(from - direction)
+	}
+	out := e.cursor.Yield()
+
+	e.steer(op.Close.Boundary)
+	if op.Close.Boundary == boundary.Clamp {
+		// A clamped cursor can only travel towards the boundary behind it
+		edge := 0
+		if direction < 0 {
+			edge = math.MaxInt
+			if e.bounded != nil {
+				edge = e.bounded.Len() - 1
+			}
+		}
+		if e.bounded == nil || e.bounded.Position() != edge {
+			e.cursor.WalkTo(edge, s)
+		}
+	} else if e.bounded != nil && e.bounded.Len() > 0 {
+		// A flowing cursor takes the shortest path unless its stride is negative, so the stride's sign depends on
+		// whether the path around the loop in the opposite direction happens to be the shortest one
+		n, position := e.bounded.Len(), e.bounded.Position()
+		end := to + direction
+		if toRelative {
+			end = position + to + 2*direction
+		}
+		distance := -direction * (((position-end)*direction%n + n) % n)
+		if distance != 0 {
+			if std.FlowDistance(distance, n, false) != distance {
+				s = -s
+			}
+			e.cursor.Walk(distance, s)
+		}
+	} else if toRelative {
+		e.cursor.Walk(to+2*direction, -s)
+	} else {
+		e.cursor.WalkTo(to+direction, -s)
+	}
+	return append(out, e.cursor.Yield()...), nil
+}
+
+// jumpFrom jumps to the first position of a range, defaulting to the provided position if it was omitted.
+func (e evaluation[T]) jumpFrom(op Operation, fallback int) ([]T, error) {
+	var target any = fallback
+	relative := op.Open.Relative
+	if op.From != nil {
+		var err error
+		if target, err = e.value(op.From); err != nil {
+			return nil, err
+		}
+		relative = relative || op.From.Relative
+	}
+
+	e.steer(op.Open.Boundary)
+	if relative {
+		e.cursor.Jump(target)
+	} else {
+		e.cursor.JumpTo(target)
+	}
+	return e.cursor.Yield(), nil
+}
+
+// end returns the final position of the cursor's data, for ranges which omit their second position.
+func (e evaluation[T]) end(op Operation) (int, error) {
+	if e.bounded != nil {
+		return e.bounded.Len() - 1, nil
+	}
+	if op.Close.Boundary == boundary.Clamp {
+		return math.MaxInt, nil
+	}
+	return 0, errorf(op.Close.Offset, "an open-ended range requires a cursor which satisfies parsel.Bounded")
+}
+
+// settled tests if a Bounded cursor already rests at the provided absolute position, so walking to it would only
+// revisit the current element.
+func (e evaluation[T]) settled(target any, mode boundary.Mode) bool {
+	if e.bounded == nil {
+		return false
+	}
+	position, ok := std.IntegerOf(target)
+	if !ok {
+		return false
+	}
+	n := e.bounded.Len()
+	switch {
+	case n == 0:
+		return false
+	case mode == boundary.Clamp:
+		position = max(0, min(position, n-1))
+	case mode == boundary.Flow:
+		position = (position%n + n) % n
+	}
+	return position == e.bounded.Position()
+}
+
+// steer sets the boundary of subsequently enqueued movements, if the cursor is Bounded.
+func (e evaluation[T]) steer(mode boundary.Mode) {
+	if e.bounded != nil {
+		e.bounded.Boundary(mode)
+	}
+}
+
+// value resolves an operand into either an int or, if it's an unsigned variable, the variable's provider itself -
+// allowing it to be revealed by the cursor at the moment of movement.
+func (e evaluation[T]) value(op *Operand) (any, error) {
+	if op.Variable != "" && !op.Negative {
+		v, err := e.variable(op)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := std.IntegerOf(v); !ok {
+			return nil, errorf(op.Offset, "variable '%s' is a %T, not an integer or a function providing one", op.Variable, v)
+		}
+		return v, nil
+	}
+	return e.integer(op)
+}
+
+// integer resolves an operand into an int, revealing it immediately if it's provided by a function.
+func (e evaluation[T]) integer(op *Operand) (int, error) {
+	value := op.Literal
+	if op.Variable != "" {
+		v, err := e.variable(op)
+		if err != nil {
+			return 0, err
+		}
+		var ok bool
+		if value, ok = std.IntegerOf(v); !ok {
+			return 0, errorf(op.Offset, "variable '%s' is a %T, not an integer or a function providing one", op.Variable, v)
+		}
+	}
+	if op.Negative {
+		value = -value
+	}
+	return value, nil
+}
+
+func (e evaluation[T]) variable(op *Operand) (any, error) {
+	for _, variables := range e.variables {
+		if v, ok := variables[op.Variable]; ok {
+			return v, nil
+		}
+	}
+	return nil, errorf(op.Offset, "undefined variable '%s'", op.Variable)
+}
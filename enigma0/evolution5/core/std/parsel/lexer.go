@@ -0,0 +1,90 @@
+package parsel
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind byte
+
+const (
+	tokenEOF tokenKind = iota
+	tokenBracket
+	tokenNumber
+	tokenIdentifier
+	tokenColon
+	tokenEquals
+	tokenComma
+	tokenTilde
+	tokenMinus
+)
+
+// A token is a single lexical element of an expression.
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+
+	// adjacent indicates that no whitespace separates this token from the one before it.
+	adjacent bool
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "the end of the expression"
+	}
+	return "'" + t.text + "'"
+}
+
+// lex breaks the provided source into its tokens, always ending with a tokenEOF.
+func lex(source string) ([]token, error) {
+	var tokens []token
+	adjacent := false
+	for offset := 0; offset < len(source); {
+		r, size := utf8.DecodeRuneInString(source[offset:])
+		if unicode.IsSpace(r) {
+			offset += size
+			adjacent = false
+			continue
+		}
+
+		t := token{offset: offset, adjacent: adjacent && len(tokens) > 0}
+		switch {
+		case r == '[' || r == ']' || r == '|' || r == '<' || r == '>':
+			t.kind = tokenBracket
+		case r == ':':
+			t.kind = tokenColon
+		case r == '=':
+			t.kind = tokenEquals
+		case r == ',':
+			t.kind = tokenComma
+		case r == '~':
+			t.kind = tokenTilde
+		case r == '-':
+			t.kind = tokenMinus
+		case r >= '0' && r <= '9':
+			t.kind = tokenNumber
+			for end := offset + size; end < len(source) && source[end] >= '0' && source[end] <= '9'; end++ {
+				size++
+			}
+		case r == '_' || unicode.IsLetter(r):
+			t.kind = tokenIdentifier
+			for end := offset + size; end < len(source); {
+				next, nextSize := utf8.DecodeRuneInString(source[end:])
+				if next != '_' && !unicode.IsLetter(next) && !unicode.IsDigit(next) {
+					break
+				}
+				size += nextSize
+				end += nextSize
+			}
+		default:
+			return nil, errorf(offset, "unexpected character %q", r)
+		}
+
+		t.text = source[offset : offset+size]
+		tokens = append(tokens, t)
+		offset += size
+		adjacent = true
+	}
+	return append(tokens, token{kind: tokenEOF, offset: len(source)}), nil
+}
@@ -0,0 +1,45 @@
+// Package parsel provides the runtime form of the "cursor accessor" mini-language described in Parselcode, which
+// lexes and parses expressions such as
+//
+//	[42]-|42:99, 4|-<<~-1>>-[[42=99, 2]
+//
+// into an Expression that can be evaluated against any slice or std.Cursorable.  Each operation is wrapped in one of
+// three kinds of brackets, which define how the cursor treats its data's boundaries:
+//
+// - [ Square Brackets ] - panic when moving outside the boundaries, like traditional index access
+//
+// - | Pipe Brackets | - clamp movement to the nearest boundary
+//
+// - < Angle Brackets > - over or underflow movement to the other side of the data
+//
+// Doubled brackets make their operand relative to the cursor's current position (as does a '~' prefix), and operations
+// may be chained directly or separated by a '-' for readability.  Within the brackets, an operation is either a single
+// position (a jump), a position and a stride (a walk), or a range - where ':' inclusively walks from the first position
+// to the second, and '=' exclusively walks between them "the long way 'round."  A ranged operation may mix its brackets,
+// where the opening bracket governs the first position and the closing bracket governs the second.
+//
+// Operands are integer literals or the names of variables provided at evaluation - either integers or functions
+// providing them.
+//
+// NOTE: Predicates and the [emit] operation are not yet part of this package.
+package parsel
+
+import "fmt"
+
+// An Error describes a problem found while parsing or evaluating an expression, positioned at the byte offset of the
+// offending input.
+type Error struct {
+	// Offset holds the byte offset into the expression's source where the problem was found.
+	Offset int
+
+	// Message describes the problem.
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("parsel: %s at offset %d", e.Message, e.Offset)
+}
+
+func errorf(offset int, format string, args ...any) *Error {
+	return &Error{Offset: offset, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,189 @@
+package parsel
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/boundary"
+)
+
+// hundred returns the integers [0, 100), so each evaluated element is also its position.
+func hundred() []int {
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+	return data
+}
+
+// stepped returns the positions from 'from' to 'to' at a rate of 'stride', always ending on 'to'.
+func stepped(from, to, stride int) []int {
+	var out []int
+	for i := from; i < to; i += stride {
+		out = append(out, i)
+	}
+	return append(out, to)
+}
+
+func TestEvaluate(t *testing.T) {
+	variables := map[string]any{"x": 3, "y": func() int { return 6 }}
+
+	tests := []struct {
+		source string
+		want   []int
+	}{
+		// Single positions
+		{source: "[42]", want: []int{42}},
+		{source: "|42|", want: []int{42}},
+		{source: "<42>", want: []int{42}},
+		{source: "|142|", want: []int{99}},
+		{source: "<142>", want: []int{42}},
+		{source: "<-1>", want: []int{99}},
+
+		// Walks
+		{source: "[42, 4]", want: stepped(0, 42, 4)[1:]},
+		{source: "[[~42, 5]]", want: stepped(0, 42, 5)[1:]},
+
+		// Ranges
+		{source: "[42:99, 4]", want: stepped(42, 99, 4)},
+		{source: "<<42:99, 4]", want: stepped(42, 99, 4)},
+		{source: "[x:y]", want: []int{3, 4, 5, 6}},
+		{source: "[97:]", want: []int{97, 98, 99}},
+		{source: "<5=90>", want: []int{4, 3, 2, 1, 0, 99, 98, 97, 96, 95, 94, 93, 92, 91}},
+		{source: "<90=5>", want: []int{91, 92, 93, 94, 95, 96, 97, 98, 99, 0, 1, 2, 3, 4}},
+		{source: "<5=90, 4>", want: []int{4, 0, 96, 92, 91}},
+		{source: "<=95>", want: []int{99, 98, 97, 96}},
+		{source: "<5=>", want: []int{4, 3, 2, 1, 0}},
+
+		// Chains
+		{source: "[42][43]", want: []int{42, 43}},
+		{source: "[42]-[[~5]]-|200|", want: []int{42, 47, 99}},
+		{source: "[42]-<<~-1>>", want: []int{42, 41}},
+		{source: "[42:45]-[[-2]]", want: []int{42, 43, 44, 45, 43}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			got, err := Evaluate(test.source, hundred(), variables)
+			if err != nil {
+				t.Fatalf("Evaluate(%q) returned %v", test.source, err)
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("Evaluate(%q) = %v, want %v", test.source, got, test.want)
+			}
+		})
+	}
+}
+
+func TestEvaluatePanics(t *testing.T) {
+	for _, source := range []string{"[200]", "[5=8]"} {
+		t.Run(source, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Evaluate(%q) did not panic", source)
+				}
+			}()
+			_, _ = Evaluate(source, hundred())
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	expr, err := Parse("[42]-<<~-1>>-|42:99, 4]")
+	if err != nil {
+		t.Fatalf("Parse returned %v", err)
+	}
+	if len(expr.Operations) != 3 {
+		t.Fatalf("parsed %d operations, want 3", len(expr.Operations))
+	}
+
+	offsets := []int{0, 5, 13}
+	for i, op := range expr.Operations {
+		if op.Offset != offsets[i] {
+			t.Errorf("operation %d is at offset %d, want %d", i, op.Offset, offsets[i])
+		}
+	}
+
+	flow := expr.Operations[1]
+	if !flow.Open.Relative || !flow.Close.Relative || flow.Open.Boundary != boundary.Flow {
+		t.Errorf("<<~-1>> parsed brackets %+v and %+v", flow.Open, flow.Close)
+	}
+	if !flow.From.Relative || !flow.From.Negative || flow.From.Literal != 1 {
+		t.Errorf("<<~-1>> parsed operand %+v", *flow.From)
+	}
+
+	mixed := expr.Operations[2]
+	if mixed.Span != Inclusive || mixed.Open.Boundary != boundary.Clamp || mixed.Close.Boundary != boundary.Panic {
+		t.Errorf("|42:99, 4] parsed as span %d from %+v to %+v", mixed.Span, mixed.Open, mixed.Close)
+	}
+	if mixed.To.Offset != 17 || mixed.Stride.Offset != 21 || mixed.Close.Offset != 22 {
+		t.Errorf("|42:99, 4] parsed offsets %d, %d, and %d", mixed.To.Offset, mixed.Stride.Offset, mixed.Close.Offset)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		source string
+		offset int
+		prefix string
+	}{
+		{source: "", offset: 0, prefix: "expected an operation"},
+		{source: "[?]", offset: 1, prefix: "unexpected character"},
+		{source: "42]", offset: 0, prefix: "expected an opening bracket"},
+		{source: "[42", offset: 3, prefix: "expected a closing bracket"},
+		{source: "[4 2]", offset: 3, prefix: "expected a closing bracket"},
+		{source: "[]", offset: 1, prefix: "expected a position"},
+		{source: "[42, ]", offset: 5, prefix: "expected a stride"},
+		{source: "[42|", offset: 3, prefix: "mismatched closing bracket"},
+		{source: "<42]", offset: 3, prefix: "mismatched closing bracket"},
+		{source: "[[42]", offset: 5, prefix: "expected a second closing"},
+		{source: "[[~42, 5]", offset: 9, prefix: "expected a second closing"},
+		{source: "[[~42, 5]-[1]", offset: 9, prefix: "expected a second closing"},
+		{source: "[42]--[1]", offset: 5, prefix: "expected an opening bracket"},
+		{source: "[42]]", offset: 4, prefix: "expected an opening bracket"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			_, err := Parse(test.source)
+			var parseErr *Error
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Parse(%q) returned %v, want an *Error", test.source, err)
+			}
+			if parseErr.Offset != test.offset {
+				t.Errorf("Parse(%q) failed at offset %d, want %d: %v", test.source, parseErr.Offset, test.offset, err)
+			}
+			if !strings.HasPrefix(parseErr.Message, test.prefix) {
+				t.Errorf("Parse(%q) failed with %q, want %q", test.source, parseErr.Message, test.prefix)
+			}
+		})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	variables := map[string]any{"s": "nope"}
+
+	tests := []struct {
+		source string
+		offset int
+	}{
+		{source: "[z]", offset: 1},
+		{source: "[42]-[s]", offset: 6},
+		{source: "|1=5, s|", offset: 6},
+	}
+
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			_, err := Evaluate(test.source, hundred(), variables)
+			var evalErr *Error
+			if !errors.As(err, &evalErr) {
+				t.Fatalf("Evaluate(%q) returned %v, want an *Error", test.source, err)
+			}
+			if evalErr.Offset != test.offset {
+				t.Errorf("Evaluate(%q) failed at offset %d, want %d: %v", test.source, evalErr.Offset, test.offset, err)
+			}
+		})
+	}
+}
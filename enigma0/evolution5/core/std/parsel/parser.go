@@ -0,0 +1,267 @@
+package parsel
+
+import (
+	"strconv"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/boundary"
+)
+
+// An Expression is a parsed chain of cursor accessor operations.
+type Expression struct {
+	// Source holds the text the expression was parsed from.
+	Source string
+
+	// Operations holds each operation of the chain, in order.
+	Operations []Operation
+}
+
+// Span defines how an Operation traverses between its two positions.
+type Span byte
+
+const (
+	// Single indicates the operation addresses a single position - jumping to it, or walking to it if given a stride.
+	Single Span = iota
+
+	// Inclusive indicates a ':' range, which walks from the first position to the second - including both.
+	Inclusive
+
+	// Exclusive indicates a '=' range, which walks from the first position to the second "the long way 'round" - excluding both.
+	Exclusive
+)
+
+// An Operation is a single bracketed movement of the cursor.
+type Operation struct {
+	// Offset holds the byte offset of the operation's opening bracket.
+	Offset int
+
+	// Open describes the opening bracket, which governs the From position.
+	Open Bracket
+
+	// Close describes the closing bracket, which governs the To position.
+	Close Bracket
+
+	// Span defines how the operation traverses between From and To.
+	Span Span
+
+	// From holds the first position, or nil if it was omitted from a range.
+	From *Operand
+
+	// To holds the second position of a range, or nil if it was omitted or the operation is Single.
+	To *Operand
+
+	// Stride holds the stride to walk at, or nil if none was provided.
+	Stride *Operand
+}
+
+// A Bracket describes one side of an Operation.
+type Bracket struct {
+	// Offset holds the byte offset of the bracket.
+	Offset int
+
+	// Boundary defines how movement governed by this bracket treats the data's boundaries.
+	Boundary boundary.Mode
+
+	// Relative indicates the bracket was doubled up.
+	Relative bool
+}
+
+// An Operand is a single integer literal or named variable within an Operation.
+type Operand struct {
+	// Offset holds the byte offset of the operand.
+	Offset int
+
+	// Relative indicates the operand was prefixed with a '~'.
+	Relative bool
+
+	// Negative indicates the operand was prefixed with a '-'.
+	Negative bool
+
+	// Literal holds the operand's value, if it isn't a Variable.
+	Literal int
+
+	// Variable holds the name of the variable providing the operand's value, if any.
+	Variable string
+}
+
+// Parse lexes and parses the provided source into an Expression, returning a positioned *Error if it's malformed.
+func Parse(source string) (*Expression, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	expr := &Expression{Source: source}
+	for p.peek().kind != tokenEOF {
+		if len(expr.Operations) > 0 && p.peek().kind == tokenMinus {
+			// Operations may optionally be separated by a '-' for readability
+			p.next()
+		}
+		op, err := p.operation()
+		if err != nil {
+			return nil, err
+		}
+		expr.Operations = append(expr.Operations, op)
+	}
+	if len(expr.Operations) == 0 {
+		return nil, errorf(len(source), "expected an operation")
+	}
+	return expr, nil
+}
+
+// MustParse parses the provided source into an Expression, panicking if it's malformed.
+func MustParse(source string) *Expression {
+	expr, err := Parse(source)
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
+
+type parser struct {
+	tokens []token
+	index  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.index]
+}
+
+func (p *parser) peekAt(ahead int) token {
+	if p.index+ahead >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.index+ahead]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.index]
+	if t.kind != tokenEOF {
+		p.index++
+	}
+	return t
+}
+
+// closerOf maps each opening bracket to its closing bracket and boundary.Mode.
+var closerOf = map[string]struct {
+	closer string
+	mode   boundary.Mode
+}{
+	"[": {"]", boundary.Panic},
+	"|": {"|", boundary.Clamp},
+	"<": {">", boundary.Flow},
+}
+
+// modeOf maps each closing bracket to its boundary.Mode.
+var modeOf = map[string]boundary.Mode{
+	"]": boundary.Panic,
+	"|": boundary.Clamp,
+	">": boundary.Flow,
+}
+
+func (p *parser) operation() (Operation, error) {
+	t := p.next()
+	opening, ok := closerOf[t.text]
+	if t.kind != tokenBracket || !ok {
+		return Operation{}, errorf(t.offset, "expected an opening bracket but found %v", t)
+	}
+	op := Operation{
+		Offset: t.offset,
+		Open:   Bracket{Offset: t.offset, Boundary: opening.mode},
+	}
+	if next := p.peek(); next.kind == tokenBracket && next.text == t.text && next.adjacent {
+		p.next()
+		op.Open.Relative = true
+	}
+
+	var err error
+	if p.startsOperand() {
+		if op.From, err = p.operand(); err != nil {
+			return op, err
+		}
+	}
+	switch p.peek().kind {
+	case tokenColon:
+		op.Span = Inclusive
+	case tokenEquals:
+		op.Span = Exclusive
+	}
+	if op.Span != Single {
+		p.next()
+		if p.startsOperand() {
+			if op.To, err = p.operand(); err != nil {
+				return op, err
+			}
+		}
+	} else if op.From == nil {
+		return op, errorf(p.peek().offset, "expected a position but found %v", p.peek())
+	}
+	if p.peek().kind == tokenComma {
+		p.next()
+		if !p.startsOperand() {
+			return op, errorf(p.peek().offset, "expected a stride but found %v", p.peek())
+		}
+		if op.Stride, err = p.operand(); err != nil {
+			return op, err
+		}
+	}
+
+	t = p.next()
+	mode, ok := modeOf[t.text]
+	if t.kind != tokenBracket || !ok {
+		return op, errorf(t.offset, "expected a closing bracket but found %v", t)
+	}
+	op.Close = Bracket{Offset: t.offset, Boundary: mode}
+	if next := p.peek(); op.Open.Relative && next.kind == tokenBracket && next.text == t.text && next.adjacent {
+		p.next()
+		op.Close.Relative = true
+	}
+	if op.Span == Single && t.text == opening.closer && op.Open.Relative && !op.Close.Relative {
+		// A doubled bracket closed only once is missing its second bracket, rather than mismatched
+		return op, errorf(p.peek().offset, "expected a second closing '%s' but found %v", t.text, p.peek())
+	}
+	if op.Span == Single && (t.text != opening.closer || op.Close.Relative != op.Open.Relative) {
+		return op, errorf(t.offset, "mismatched closing bracket %v - only ranges may mix their brackets", t)
+	}
+	return op, nil
+}
+
+// startsOperand tests if the next token begins an operand.
+func (p *parser) startsOperand() bool {
+	switch p.peek().kind {
+	case tokenTilde, tokenNumber, tokenIdentifier:
+		return true
+	case tokenMinus:
+		next := p.peekAt(1)
+		return next.adjacent && (next.kind == tokenNumber || next.kind == tokenIdentifier)
+	default:
+		return false
+	}
+}
+
+func (p *parser) operand() (*Operand, error) {
+	op := &Operand{Offset: p.peek().offset}
+	if p.peek().kind == tokenTilde {
+		p.next()
+		op.Relative = true
+	}
+	if p.peek().kind == tokenMinus {
+		p.next()
+		op.Negative = true
+	}
+
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		value, err := strconv.Atoi(t.text)
+		if err != nil {
+			return nil, errorf(t.offset, "%v is not a valid integer", t)
+		}
+		op.Literal = value
+	case tokenIdentifier:
+		op.Variable = t.text
+	default:
+		return nil, errorf(t.offset, "expected an integer or variable but found %v", t)
+	}
+	return op, nil
+}
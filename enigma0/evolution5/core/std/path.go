@@ -8,16 +8,26 @@ import (
 type Path []any
 
 func (p Path) sanityCheck() {
-	StringifyMany(p)
+	StringifyMany(p...)
 }
 
 // String outputs the Path's steps as a '⇝' delimited string, minus any code information.
 func (p Path) String() string {
-	return strings.Join(StringifyMany(p), "⇝")
+	return strings.Join(StringifyMany(p...), "⇝")
 }
 
+// Swizzle returns the Path's steps found at the provided positions, in the order requested.  Positions may be repeated,
+// and any position beyond the end of the path yields nil.
+//
+// NOTE: To swizzle the members of an arbitrary target, please use std.Swizzle.
 func (p Path) Swizzle(positions ...uint) []any {
-
+	out := make([]any, len(positions))
+	for i, position := range positions {
+		if position < uint(len(p)) {
+			out[i] = p[position]
+		}
+	}
+	return out
 }
 
 // homoiconicity
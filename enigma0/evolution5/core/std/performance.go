@@ -0,0 +1,75 @@
+package std
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// PerformancePath is the root Path beneath which every Statistic in the performance registry lives.
+var PerformancePath = Path{"Performance"}
+
+// A Measurement pairs a registered Statistic with the full Path it was registered at.
+type Measurement struct {
+	Path      Path
+	Statistic *Statistic
+}
+
+var performance = struct {
+	sync.Mutex
+	measurements map[string]Measurement
+}{
+	measurements: make(map[string]Measurement),
+}
+
+// Performance returns the Statistic registered at the provided steps beneath PerformancePath, creating it if
+// this is the first time it's been asked for.  For example -
+//
+//	std.Performance("Epiphanies", "Decay").Hit()
+//
+// ...records a hit into the Statistic at std.Path{"Performance", "Epiphanies", "Decay"}
+//
+// NOTE: Epiphanies, gates, and glitter's frames report here on their own, while synapses report through Impulse.Record
+// beneath std.Path{"Performance", "Synapses", Bridge...}
+func Performance(steps ...any) *Statistic {
+	path := append(slices.Clone(PerformancePath), steps...)
+	key := path.String()
+
+	performance.Lock()
+	defer performance.Unlock()
+
+	if m, ok := performance.measurements[key]; ok {
+		return m.Statistic
+	}
+	s := NewStatistic()
+	performance.measurements[key] = Measurement{
+		Path:      path,
+		Statistic: s,
+	}
+	return s
+}
+
+// PerformanceSnapshot returns every registered Measurement whose Path begins with the provided steps beneath
+// PerformancePath, ordered by their Path.  If no steps are provided, every Measurement is returned.
+//
+// NOTE: The slice is thread-independent, but the statistics it points to remain live.
+func PerformanceSnapshot(prefix ...any) []Measurement {
+	steps := StringifyMany(append(slices.Clone(PerformancePath), prefix...)...)
+
+	performance.Lock()
+	out := make([]Measurement, 0, len(performance.measurements))
+	for _, m := range performance.measurements {
+		if len(m.Path) < len(steps) {
+			continue
+		}
+		if slices.Equal(StringifyMany(m.Path[:len(steps)]...), steps) {
+			out = append(out, m)
+		}
+	}
+	performance.Unlock()
+
+	slices.SortFunc(out, func(a, b Measurement) int {
+		return strings.Compare(a.Path.String(), b.Path.String())
+	})
+	return out
+}
@@ -0,0 +1,42 @@
+package std
+
+import (
+	"testing"
+	"time"
+)
+
+// forgetPerformance removes every Statistic beneath the provided steps of the performance registry, so a test can
+// run more than once without counting the hits of its previous runs.
+func forgetPerformance(prefix ...any) {
+	stale := PerformanceSnapshot(prefix...)
+	performance.Lock()
+	defer performance.Unlock()
+	for _, m := range stale {
+		delete(performance.measurements, m.Path.String())
+	}
+}
+
+func TestImpulseRecordReportsSynapses(t *testing.T) {
+	forgetPerformance("Synapses", "Record Test")
+	inception := time.Now()
+	activated := inception.Add(time.Millisecond)
+	completed := activated.Add(5 * time.Millisecond)
+	imp := &Impulse{Bridge: Path{"Record Test", "Synapse"}, Inception: inception, Activated: &activated, Completed: &completed}
+
+	imp.Record()
+	imp.Completed = nil
+	imp.Record()
+
+	synapse := Performance("Synapses", "Record Test", "Synapse")
+	if count := synapse.Count(); count != 2 {
+		t.Fatalf("recorded %d synaptic activations, want 2", count)
+	}
+	if p := synapse.Percentile(50); p != 5*time.Millisecond {
+		t.Errorf("recorded a run time of %v, want 5ms", p)
+	}
+
+	snapshot := PerformanceSnapshot("Synapses", "Record Test")
+	if len(snapshot) != 1 || snapshot[0].Statistic != synapse {
+		t.Errorf("snapshot %v, want only the synapse's statistic", snapshot)
+	}
+}
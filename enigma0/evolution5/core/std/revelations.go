@@ -0,0 +1,224 @@
+package std
+
+import (
+	"container/list"
+	"image"
+	"reflect"
+	"sync"
+)
+
+// An evictable is anything the Revelations manager can ask to release its materialized form early.
+type evictable interface {
+	// evict should release the materialized form and forget it from the manager - while still guarding that form -
+	// then return true, or false if it's currently unable to.
+	evict() bool
+}
+
+type revelations struct {
+	sync.Mutex
+	budget  uint
+	usage   uint
+	recency *list.List
+	entries map[evictable]*list.Element
+}
+
+type revelation struct {
+	owner evictable
+	size  uint
+}
+
+// Revelations is the global manager of every materialized Epiphany.  It tracks the approximate size of each revelation
+// and, when given a Budget, decays the least recently revealed epiphanies early to stay within it.
+//
+// NOTE: Every early eviction records its size into std.Path{"Performance", "Epiphanies", "Evict"}
+var Revelations = &revelations{
+	recency: list.New(),
+	entries: make(map[evictable]*list.Element),
+}
+
+var epiphanyEvictions = Performance("Epiphanies", "Evict")
+
+// Budget sets and/or gets the approximate number of bytes all materialized epiphanies may occupy at once.  If no value
+// is provided, this just returns the budget - if values are provided, the first is set as the budget before returning it.
+//
+// NOTE: A zero budget (default) is unbounded, leaving each epiphany to decay on its own timer.
+func (r *revelations) Budget(bytes ...uint) uint {
+	r.Lock()
+	if len(bytes) == 0 {
+		defer r.Unlock()
+		return r.budget
+	}
+	r.budget = bytes[0]
+	r.Unlock()
+
+	r.enforce(nil)
+	return bytes[0]
+}
+
+// Usage returns the approximate number of bytes currently occupied by materialized epiphanies.
+func (r *revelations) Usage() uint {
+	r.Lock()
+	defer r.Unlock()
+	return r.usage
+}
+
+// Count returns the number of epiphanies currently materialized.
+func (r *revelations) Count() uint {
+	r.Lock()
+	defer r.Unlock()
+	return uint(len(r.entries))
+}
+
+// touch marks the owner as the most recently revealed with the provided size.
+//
+// NOTE: This must be called while the owner guards its revelation, so it can never re-track a revelation the owner
+// has just forgotten - then, once that guard is released, call enforce.
+func (r *revelations) touch(owner evictable, size uint) {
+	r.Lock()
+	defer r.Unlock()
+
+	if element, ok := r.entries[owner]; ok {
+		entry := element.Value.(*revelation)
+		r.usage -= entry.size
+		entry.size = size
+		r.recency.MoveToFront(element)
+	} else {
+		r.entries[owner] = r.recency.PushFront(&revelation{owner: owner, size: size})
+	}
+	r.usage += size
+}
+
+// forget stops tracking the owner, typically because its revelation has decayed.
+//
+// NOTE: Just like touch, this must be called while the owner guards its revelation.
+func (r *revelations) forget(owner evictable) {
+	r.Lock()
+	defer r.Unlock()
+
+	element, ok := r.entries[owner]
+	if !ok {
+		return
+	}
+	entry := element.Value.(*revelation)
+	r.usage -= entry.size
+	r.recency.Remove(element)
+	delete(r.entries, owner)
+}
+
+// enforce evicts the least recently revealed epiphanies, other than the one provided, until the budget is satisfied.
+//
+// NOTE: Victims are chosen while holding the manager's lock but evicted after releasing it, and a victim which is busy
+// (or holds uncommitted revisions) is simply skipped in favor of the next - so an eviction can never deadlock against
+// an active Reveal.
+func (r *revelations) enforce(keep evictable) {
+	r.Lock()
+	var victims []revelation
+	if r.budget > 0 && r.usage > r.budget {
+		for element := r.recency.Back(); element != nil; element = element.Prev() {
+			if entry := element.Value.(*revelation); entry.owner != keep {
+				victims = append(victims, *entry)
+			}
+		}
+	}
+	r.Unlock()
+
+	for _, victim := range victims {
+		r.Lock()
+		satisfied := r.budget == 0 || r.usage <= r.budget
+		r.Unlock()
+		if satisfied {
+			return
+		}
+
+		if victim.owner.evict() {
+			epiphanyEvictions.Hit(victim.size)
+		}
+	}
+}
+
+// approximateSize estimates how many bytes the provided value occupies in memory by walking its structure.
+//
+// NOTE: Images are measured by their pixel buffers, while everything else is walked through reflection.
+func approximateSize(value any) uint {
+	if img, ok := value.(image.Image); ok {
+		bounds := img.Bounds()
+		bytesPerPixel := uint(4)
+		switch img.(type) {
+		case *image.Gray, *image.Alpha, *image.Paletted:
+			bytesPerPixel = 1
+		case *image.Gray16, *image.Alpha16:
+			bytesPerPixel = 2
+		case *image.RGBA64, *image.NRGBA64:
+			bytesPerPixel = 8
+		}
+		return uint(bounds.Dx()*bounds.Dy()) * bytesPerPixel
+	}
+	return sizeOfValue(reflect.ValueOf(value), make(map[uintptr]bool))
+}
+
+func sizeOfValue(v reflect.Value, visited map[uintptr]bool) uint {
+	if !v.IsValid() {
+		return 0
+	}
+
+	size := uint(v.Type().Size())
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || visited[v.Pointer()] {
+			return size
+		}
+		visited[v.Pointer()] = true
+		return size + sizeOfValue(v.Elem(), visited)
+	case reflect.Interface:
+		if v.IsNil() {
+			return size
+		}
+		if !v.CanInterface() {
+			return size + sizeOfValue(v.Elem(), visited)
+		}
+		if img, ok := v.Interface().(image.Image); ok {
+			return size + approximateSize(img)
+		}
+		return size + sizeOfValue(v.Elem(), visited)
+	case reflect.String:
+		return size + uint(v.Len())
+	case reflect.Slice:
+		if v.IsNil() || visited[v.Pointer()] {
+			return size
+		}
+		visited[v.Pointer()] = true
+		elem := v.Type().Elem()
+		if elem.Kind() <= reflect.Complex128 {
+			// Plain numeric elements can be measured without visiting each one
+			return size + uint(v.Cap())*uint(elem.Size())
+		}
+		for i := 0; i < v.Len(); i++ {
+			size += sizeOfValue(v.Index(i), visited)
+		}
+		return size
+	case reflect.Map:
+		if v.IsNil() || visited[v.Pointer()] {
+			return size
+		}
+		visited[v.Pointer()] = true
+		iter := v.MapRange()
+		for iter.Next() {
+			size += sizeOfValue(iter.Key(), visited) + sizeOfValue(iter.Value(), visited)
+		}
+		return size
+	case reflect.Struct:
+		size = 0
+		for i := 0; i < v.NumField(); i++ {
+			size += sizeOfValue(v.Field(i), visited)
+		}
+		return max(size, uint(v.Type().Size()))
+	case reflect.Array:
+		size = 0
+		for i := 0; i < v.Len(); i++ {
+			size += sizeOfValue(v.Index(i), visited)
+		}
+		return max(size, uint(v.Type().Size()))
+	default:
+		return size
+	}
+}
@@ -0,0 +1,136 @@
+package std
+
+import (
+	"container/list"
+	"image"
+	"testing"
+	"time"
+)
+
+// A fakeRevelation is an evictable which forgets itself from its manager whenever it agrees to be evicted.
+type fakeRevelation struct {
+	manager *revelations
+	busy    bool
+	evicted int
+}
+
+func (f *fakeRevelation) evict() bool {
+	if f.busy {
+		return false
+	}
+	f.evicted++
+	f.manager.forget(f)
+	return true
+}
+
+// isolatedRevelations creates an empty manager of revelations, unaffected by any epiphanies other tests revealed.
+func isolatedRevelations() *revelations {
+	return &revelations{recency: list.New(), entries: make(map[evictable]*list.Element)}
+}
+
+func TestRevelationsEvictLeastRecent(t *testing.T) {
+	r := isolatedRevelations()
+	a, b, c := &fakeRevelation{manager: r}, &fakeRevelation{manager: r, busy: true}, &fakeRevelation{manager: r}
+
+	r.touch(a, 100)
+	r.touch(b, 100)
+	r.touch(c, 100)
+	r.Budget(250)
+	if a.evicted != 1 || b.evicted != 0 || c.evicted != 0 {
+		t.Fatalf("evicted %d, %d, and %d times, want only the least recent", a.evicted, b.evicted, c.evicted)
+	}
+	if r.Usage() != 200 || r.Count() != 2 {
+		t.Fatalf("using %d bytes across %d revelations, want 200 across 2", r.Usage(), r.Count())
+	}
+
+	// The least recent is busy, so the next in line is evicted instead - but never the revelation just touched
+	r.touch(a, 100)
+	r.enforce(a)
+	if b.evicted != 0 || c.evicted != 1 || a.evicted != 1 {
+		t.Fatalf("evicted %d, %d, and %d times, want the busy revelation skipped", a.evicted, b.evicted, c.evicted)
+	}
+
+	// Touching an existing revelation replaces its size rather than adding to it
+	r.Budget(0)
+	r.touch(b, 10)
+	if r.Usage() != 110 || r.Count() != 2 {
+		t.Fatalf("using %d bytes across %d revelations, want 110 across 2", r.Usage(), r.Count())
+	}
+}
+
+func TestRevelationsTrackEpiphanies(t *testing.T) {
+	sized := func() *Epiphany[int, []byte] {
+		epi := NewEpiphany(func(ideal int) ([]byte, error) {
+			return make([]byte, ideal), nil
+		}, time.Hour)
+		epi.SizeFn(func(material []byte) uint { return uint(len(material)) })
+		epi.Describe(100)
+		return epi
+	}
+	// NOTE: Other tests share the global manager, so only this test's own entries are inspected
+	tracked := func(epi *Epiphany[int, []byte]) (uint, bool) {
+		Revelations.Lock()
+		defer Revelations.Unlock()
+		if element, ok := Revelations.entries[epi]; ok {
+			return element.Value.(*revelation).size, true
+		}
+		return 0, false
+	}
+
+	epi := sized()
+	epi.Reveal()
+	if size, ok := tracked(epi); !ok || size != 100 {
+		t.Fatalf("tracked %d bytes (%v), want 100", size, ok)
+	}
+
+	// A described epiphany has discarded its revelation, so it shouldn't linger as a ghost entry
+	epi.Describe(50)
+	if _, ok := tracked(epi); ok {
+		t.Fatal("expected the described epiphany to be forgotten")
+	}
+	epi.Reveal()
+	if size, ok := tracked(epi); !ok || size != 50 {
+		t.Fatalf("tracked %d bytes (%v) after rematerializing, want 50", size, ok)
+	}
+
+	// An evicted epiphany forgets itself, and a decayed one likewise
+	if !epi.evict() {
+		t.Fatal("expected a clean epiphany to agree to eviction")
+	}
+	if _, ok := tracked(epi); ok {
+		t.Fatal("expected the evicted epiphany to be forgotten")
+	}
+	epi.Reveal()
+	epi.decompose()
+	if _, ok := tracked(epi); ok {
+		t.Fatal("expected the decayed epiphany to be forgotten")
+	}
+}
+
+func TestApproximateSize(t *testing.T) {
+	if size := approximateSize(image.NewRGBA(image.Rect(0, 0, 10, 10))); size != 400 {
+		t.Errorf("measured an RGBA image at %d bytes, want 400", size)
+	}
+	if size := approximateSize(image.NewGray(image.Rect(0, 0, 10, 10))); size != 100 {
+		t.Errorf("measured a gray image at %d bytes, want 100", size)
+	}
+	if size := approximateSize(make([]int64, 10)); size < 80 {
+		t.Errorf("measured ten int64s at %d bytes, want at least 80", size)
+	}
+
+	// Self-referencing structures are measured once, rather than forever
+	type node struct {
+		name string
+		next *node
+	}
+	loop := &node{name: "loop"}
+	loop.next = loop
+	if size := approximateSize(loop); size == 0 {
+		t.Error("expected a self-referencing pointer to be measured")
+	}
+	self := map[string]any{"payload": make([]byte, 100)}
+	self["self"] = self
+	if size := approximateSize(self); size < 100 {
+		t.Errorf("measured a self-containing map at %d bytes, want at least 100", size)
+	}
+}
@@ -0,0 +1,302 @@
+package std
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/boundary"
+)
+
+// A SliceCursor is a Cursorable which traverses the elements of a slice.  Its movements are lazily enqueued, each
+// remembering the boundary.Mode it was enqueued with, until a call to Yield performs them in order.
+//
+// NOTE: A boundary.Flow cursor walks the shortest path around its data to reach a target, while a negative stride takes
+// the "long way 'round" instead - see FlowDistance.  This is only defined for a boundary.Flow cursor - a boundary.Panic
+// cursor would travel forever, and a boundary.Clamp cursor has only one way to go, so both will panic.
+type SliceCursor[T any] struct {
+	data     []T
+	position int
+	mode     boundary.Mode
+	pending  []movement
+}
+
+// A movement is a single enqueued cursor operation.
+type movement struct {
+	relative bool
+	walk     bool
+	along    bool
+	target   any
+	stride   any
+	mode     boundary.Mode
+}
+
+// NewSliceCursor creates a SliceCursor resting at the first element of the provided data, which treats the data's
+// boundaries according to the provided boundary.Mode.
+func NewSliceCursor[T any](data []T, mode boundary.Mode) *SliceCursor[T] {
+	return &SliceCursor[T]{data: data, mode: mode}
+}
+
+// Len returns the number of elements the cursor traverses.
+func (c *SliceCursor[T]) Len() int {
+	return len(c.data)
+}
+
+// Position returns the index of the cursor's current element.
+//
+// NOTE: Enqueued movements aren't performed until a call to Yield, so this won't reflect them until then.
+func (c *SliceCursor[T]) Position() int {
+	return c.position
+}
+
+// Boundary sets and/or gets how subsequently enqueued movements treat the data's boundaries.  If no value is provided,
+// this just returns the mode - if values are provided, the first is set as the mode before returning it.
+//
+// NOTE: Movements which were already enqueued keep the mode they were enqueued with.
+func (c *SliceCursor[T]) Boundary(mode ...boundary.Mode) boundary.Mode {
+	if len(mode) > 0 {
+		c.mode = mode[0]
+	}
+	return c.mode
+}
+
+func (c *SliceCursor[T]) enqueue(m movement) Cursorable[T] {
+	m.mode = c.mode
+	c.pending = append(c.pending, m)
+	return c
+}
+
+// Jump enqueues a relative jump 𝑛 positions forwards or backwards - see Cursorable.Jump.
+func (c *SliceCursor[T]) Jump(n any) Cursorable[T] {
+	return c.enqueue(movement{relative: true, target: n})
+}
+
+// JumpTo enqueues an absolute jump to position 𝑖 - see Cursorable.JumpTo.
+func (c *SliceCursor[T]) JumpTo(i any) Cursorable[T] {
+	return c.enqueue(movement{target: i})
+}
+
+// JumpAlong enqueues a jump to each of the provided steps - see Cursorable.JumpAlong.
+func (c *SliceCursor[T]) JumpAlong(steps any, relative bool) Cursorable[T] {
+	return c.enqueue(movement{relative: relative, along: true, target: steps})
+}
+
+// Walk enqueues a relative walk 𝑛 positions forwards or backwards at a rate of 'stride' - see Cursorable.Walk.
+func (c *SliceCursor[T]) Walk(n any, stride any) Cursorable[T] {
+	return c.enqueue(movement{relative: true, walk: true, target: n, stride: stride})
+}
+
+// WalkTo enqueues an absolute walk to position 𝑖 at a rate of 'stride' - see Cursorable.WalkTo.
+func (c *SliceCursor[T]) WalkTo(i any, stride any) Cursorable[T] {
+	return c.enqueue(movement{walk: true, target: i, stride: stride})
+}
+
+// WalkAlong enqueues a walk to each of the provided steps at a rate of 'stride' - see Cursorable.WalkAlong.
+func (c *SliceCursor[T]) WalkAlong(steps any, stride any, relative bool) Cursorable[T] {
+	return c.enqueue(movement{relative: relative, walk: true, along: true, target: steps, stride: stride})
+}
+
+// Current returns the element at the cursor's current position, without performing or affecting any enqueued movements.
+//
+// NOTE: If the data is empty, this returns the zero value of T.
+func (c *SliceCursor[T]) Current() T {
+	var zero T
+	if len(c.data) == 0 {
+		return zero
+	}
+	return c.data[c.position]
+}
+
+// Yield performs every enqueued movement in order, returning each element they visited.
+func (c *SliceCursor[T]) Yield() []T {
+	pending := c.pending
+	c.pending = nil
+
+	out := make([]T, 0, len(pending))
+	for _, m := range pending {
+		out = c.perform(m, out)
+	}
+	return out
+}
+
+func (c *SliceCursor[T]) perform(m movement, out []T) []T {
+	if m.along {
+		next := stepsOf(m.target)
+		for target, stride, ok := next(); ok; target, stride, ok = next() {
+			step := m
+			step.along = false
+			step.target = target
+			if stride != nil {
+				step.stride = stride
+			}
+			out = c.perform(step, out)
+		}
+		return out
+	}
+
+	target := mustInteger(m.target)
+	if m.relative {
+		target += c.position
+	}
+	if m.walk {
+		return c.walk(target, m.stride, m.mode, out)
+	}
+	return c.jump(target, m.mode, out)
+}
+
+func (c *SliceCursor[T]) jump(target int, mode boundary.Mode, out []T) []T {
+	if len(c.data) == 0 {
+		if mode == boundary.Panic {
+			panic(fmt.Errorf("cursor position %d is out of range [0:0]", target))
+		}
+		return out
+	}
+	c.position = c.resolve(target, mode)
+	return append(out, c.data[c.position])
+}
+
+func (c *SliceCursor[T]) walk(target int, stride any, mode boundary.Mode, out []T) []T {
+	n := len(c.data)
+	if n == 0 {
+		if mode == boundary.Panic {
+			panic(fmt.Errorf("cursor position %d is out of range [0:0]", target))
+		}
+		return out
+	}
+	s := mustInteger(stride)
+	if s == 0 {
+		return out
+	}
+
+	var distance int
+	switch mode {
+	case boundary.Clamp:
+		if s < 0 {
+			panic(fmt.Errorf("a clamped cursor can't take the long way 'round with a negative stride"))
+		}
+		distance = c.resolve(target, mode) - c.position
+	case boundary.Flow:
+		distance = FlowDistance(target-c.position, n, s < 0)
+	default:
+		if s < 0 {
+			panic(fmt.Errorf("a panicking cursor can't take the long way 'round - infinity is undefined"))
+		}
+		distance = target - c.position
+	}
+
+	if distance == 0 {
+		return append(out, c.data[c.position])
+	}
+	direction, remaining := cmp.Compare(distance, 0), abs(distance)
+	for remaining > 0 && s != 0 {
+		step := min(abs(s), remaining)
+		remaining -= step
+		c.position = c.resolve(c.position+direction*step, mode)
+		out = append(out, c.data[c.position])
+
+		// Providers are revealed between each step, allowing a dynamic stride
+		s = mustInteger(stride)
+	}
+	return out
+}
+
+// resolve translates a raw position into the data's boundaries according to the provided mode.
+func (c *SliceCursor[T]) resolve(position int, mode boundary.Mode) int {
+	n := len(c.data)
+	if position >= 0 && position < n {
+		return position
+	}
+	switch mode {
+	case boundary.Clamp:
+		return max(0, min(position, n-1))
+	case boundary.Flow:
+		return (position%n + n) % n
+	default:
+		panic(fmt.Errorf("cursor position %d is out of range [0:%d]", position, n))
+	}
+}
+
+// stepsOf returns a function which yields each step of the provided 'along' steps - be they a single position, a
+// slice of positions, or a function providing positions.  A provider may follow each position with the stride to walk
+// to it at, and is exhausted once it provides a nil position or a trailing false - such as a func() any, a func() *int,
+// a func() (int, bool), or a func() (*int, int).
+//
+// NOTE: A provider which can do neither, such as a func() int, could never be exhausted - so it panics instead.
+func stepsOf(steps any) func() (target any, stride any, ok bool) {
+	exhausted := func() (any, any, bool) { return nil, nil, false }
+	if steps == nil {
+		return exhausted
+	}
+
+	v := reflect.ValueOf(steps)
+	switch v.Kind() {
+	case reflect.Func:
+		return providerOf(v)
+	case reflect.Slice, reflect.Array:
+		i := 0
+		return func() (any, any, bool) {
+			if i >= v.Len() {
+				return nil, nil, false
+			}
+			i++
+			return v.Index(i - 1).Interface(), nil, true
+		}
+	default:
+		done := false
+		return func() (any, any, bool) {
+			if done {
+				return nil, nil, false
+			}
+			done = true
+			return steps, nil, true
+		}
+	}
+}
+
+// providerOf reflectively calls a step provider - see stepsOf.
+func providerOf(provider reflect.Value) func() (any, any, bool) {
+	t := provider.Type()
+	if provider.IsNil() || t.NumIn() != 0 || t.NumOut() < 1 || t.NumOut() > 2 {
+		panic(fmt.Errorf("a %v can't provide cursor steps", t))
+	}
+	comma := t.NumOut() == 2 && t.Out(1).Kind() == reflect.Bool
+	nillable := false
+	switch t.Out(0).Kind() {
+	case reflect.Interface, reflect.Pointer, reflect.Func:
+		nillable = true
+	}
+	if !comma && !nillable {
+		panic(fmt.Errorf("a %v can never be exhausted - provide a nillable position or a trailing bool", t))
+	}
+
+	return func() (any, any, bool) {
+		results := provider.Call(nil)
+		position := results[0]
+		if (comma && !results[1].Bool()) || (nillable && position.IsNil()) {
+			return nil, nil, false
+		}
+		if position.Kind() == reflect.Pointer {
+			position = position.Elem()
+		}
+		var stride any
+		if len(results) == 2 && !comma {
+			stride = results[1].Interface()
+		}
+		return position.Interface(), stride, true
+	}
+}
+
+func mustInteger(value any) int {
+	i, ok := IntegerOf(value)
+	if !ok {
+		panic(fmt.Errorf("a %T can't be used as a cursor position or stride", value))
+	}
+	return i
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,231 @@
+package std
+
+import (
+	"slices"
+	"testing"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/boundary"
+)
+
+// positions returns the data's elements for the provided positions, for comparison against a SliceCursor's yield.
+func positions(from, to, stride int) []int {
+	var out []int
+	if from <= to {
+		for i := from; i < to; i += stride {
+			out = append(out, i)
+		}
+	} else {
+		for i := from; i > to; i -= stride {
+			out = append(out, i)
+		}
+	}
+	return append(out, to)
+}
+
+// flowed returns the positions visited by walking 'distance' from 'start' at a rate of 'stride', wrapping around 'n'.
+func flowed(start, distance, stride, n int) []int {
+	var out []int
+	direction, remaining := 1, distance
+	if distance < 0 {
+		direction, remaining = -1, -distance
+	}
+	for remaining > 0 {
+		step := min(stride, remaining)
+		remaining -= step
+		start = ((start+direction*step)%n + n) % n
+		out = append(out, start)
+	}
+	return out
+}
+
+func TestSliceCursor(t *testing.T) {
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i
+	}
+
+	tests := []struct {
+		name   string
+		mode   boundary.Mode
+		start  int
+		move   func(c *SliceCursor[int])
+		want   []int
+		panics bool
+	}{
+		// Jumping
+		{name: "[[~0]] Jump(0)", start: 50, move: func(c *SliceCursor[int]) { c.Jump(0) }, want: []int{50}},
+		{name: "[[0]] JumpTo(0)", start: 50, move: func(c *SliceCursor[int]) { c.JumpTo(0) }, want: []int{0}},
+		{name: "[[~42]] Jump(42)", start: 50, move: func(c *SliceCursor[int]) { c.Jump(42) }, want: []int{92}},
+		{name: "[[~-42]] Jump(-42)", start: 50, move: func(c *SliceCursor[int]) { c.Jump(-42) }, want: []int{8}},
+		{name: "[[42]] JumpTo(42)", start: 50, move: func(c *SliceCursor[int]) { c.JumpTo(42) }, want: []int{42}},
+		{name: "[[-42]] JumpTo(-42) panic", start: 50, move: func(c *SliceCursor[int]) { c.JumpTo(-42) }, panics: true},
+		{name: "|-42| JumpTo(-42) clamp", mode: boundary.Clamp, start: 50, move: func(c *SliceCursor[int]) { c.JumpTo(-42) }, want: []int{0}},
+		{name: "<-42> JumpTo(-42) flow", mode: boundary.Flow, start: 50, move: func(c *SliceCursor[int]) { c.JumpTo(-42) }, want: []int{58}},
+		{name: "<-1> tail index", mode: boundary.Flow, move: func(c *SliceCursor[int]) { c.JumpTo(-1) }, want: []int{99}},
+
+		// Walking
+		{name: "[[~42, 0]] Walk(42, 0)", start: 50, move: func(c *SliceCursor[int]) { c.Walk(42, 0) }, want: nil},
+		{name: "[[~0, 1]] Walk(0, 1)", start: 50, move: func(c *SliceCursor[int]) { c.Walk(0, 1) }, want: []int{50}},
+		{name: "[[0, 1]] WalkTo(0, 1)", start: 5, move: func(c *SliceCursor[int]) { c.WalkTo(0, 1) }, want: []int{4, 3, 2, 1, 0}},
+		{name: "[[~42, 1]] Walk(42, 1)", start: 50, move: func(c *SliceCursor[int]) { c.Walk(42, 1) }, want: positions(51, 92, 1)},
+		{name: "[[~-42, 1]] Walk(-42, 1)", start: 50, move: func(c *SliceCursor[int]) { c.Walk(-42, 1) }, want: positions(49, 8, 1)},
+		{name: "[[42, 1]] WalkTo(42, 1)", start: 50, move: func(c *SliceCursor[int]) { c.WalkTo(42, 1) }, want: positions(49, 42, 1)},
+		{name: "[[-42, 1]] WalkTo(-42, 1) panic", start: 50, move: func(c *SliceCursor[int]) { c.WalkTo(-42, 1) }, panics: true},
+		{name: "|-42, 1| WalkTo(-42, 1) clamp", mode: boundary.Clamp, start: 50, move: func(c *SliceCursor[int]) { c.WalkTo(-42, 1) }, want: positions(49, 0, 1)},
+		{name: "<-42, 1> WalkTo(-42, 1) flow", mode: boundary.Flow, start: 50, move: func(c *SliceCursor[int]) { c.WalkTo(-42, 1) }, want: positions(51, 58, 1)},
+		{name: "[[~42, 5]] Walk(42, 5)", start: 50, move: func(c *SliceCursor[int]) { c.Walk(42, 5) }, want: positions(55, 92, 5)},
+		{name: "[[~-42, 5]] Walk(-42, 5)", start: 50, move: func(c *SliceCursor[int]) { c.Walk(-42, 5) }, want: positions(45, 8, 5)},
+		{name: "[[42, 5]] WalkTo(42, 5)", start: 50, move: func(c *SliceCursor[int]) { c.WalkTo(42, 5) }, want: []int{45, 42}},
+		{name: "<-42, 5> WalkTo(-42, 5) flow", mode: boundary.Flow, start: 50, move: func(c *SliceCursor[int]) { c.WalkTo(-42, 5) }, want: []int{55, 58}},
+
+		// Bounded contexts
+		{name: "[[42, -5]] WalkTo(42, -5) panic", start: 50, move: func(c *SliceCursor[int]) { c.WalkTo(42, -5) }, panics: true},
+		{name: "[[~42, -5]] Walk(42, -5) panic", start: 50, move: func(c *SliceCursor[int]) { c.Walk(42, -5) }, panics: true},
+		{name: "|42, -5| WalkTo(42, -5) clamp", mode: boundary.Clamp, start: 50, move: func(c *SliceCursor[int]) { c.WalkTo(42, -5) }, panics: true},
+		{name: "||~42, -5|| Walk(42, -5) clamp", mode: boundary.Clamp, start: 50, move: func(c *SliceCursor[int]) { c.Walk(42, -5) }, panics: true},
+		{name: "<42, -5> WalkTo(42, -5) flow", mode: boundary.Flow, start: 50, move: func(c *SliceCursor[int]) { c.WalkTo(42, -5) }, want: flowed(50, 92, 5, 100)},
+		{name: "<<~42, -5>> Walk(42, -5) flow", mode: boundary.Flow, start: 50, move: func(c *SliceCursor[int]) { c.Walk(42, -5) }, want: flowed(50, -58, 5, 100)},
+		{name: "<0, 1> WalkTo(0, 1) shortest", mode: boundary.Flow, start: 99, move: func(c *SliceCursor[int]) { c.WalkTo(0, 1) }, want: []int{0}},
+		{name: "<0, -1> WalkTo(0, -1) long way", mode: boundary.Flow, start: 99, move: func(c *SliceCursor[int]) { c.WalkTo(0, -1) }, want: positions(98, 0, 1)},
+		{name: "<<~142, 10>> Walk(142, 10) shortest", mode: boundary.Flow, start: 50, move: func(c *SliceCursor[int]) { c.Walk(142, 10) }, want: flowed(50, 42, 10, 100)},
+		{name: "<<~-58, -10>> Walk(-58, -10) long way", mode: boundary.Flow, start: 50, move: func(c *SliceCursor[int]) { c.Walk(-58, -10) }, want: flowed(50, -58, 10, 100)},
+		{name: "<<~0, -10>> Walk(0, -10) full loop", mode: boundary.Flow, start: 50, move: func(c *SliceCursor[int]) { c.Walk(0, -10) }, want: flowed(50, -100, 10, 100)},
+
+		// Chains
+		{name: "[42][[~42]]", move: func(c *SliceCursor[int]) { c.JumpTo(42).Jump(42) }, want: []int{42, 84}},
+		{name: "|42|[[~99, 5]]", mode: boundary.Clamp, move: func(c *SliceCursor[int]) { c.JumpTo(42).Walk(99, 5) }, want: append([]int{42}, positions(47, 99, 5)...)},
+		{name: "|42|[[~-99, 5]]", mode: boundary.Clamp, move: func(c *SliceCursor[int]) { c.JumpTo(42).Walk(-99, 5) }, want: append([]int{42}, positions(37, 0, 5)...)},
+		{name: "[42][[99, 5]]", move: func(c *SliceCursor[int]) { c.JumpTo(42).WalkTo(99, 5) }, want: append([]int{42}, positions(47, 99, 5)...)},
+		{name: "<42>[[-99, 5]]", mode: boundary.Flow, move: func(c *SliceCursor[int]) { c.JumpTo(42).WalkTo(-99, 5) }, want: append([]int{42}, flowed(42, -41, 5, 100)...)},
+
+		// Along
+		{name: "JumpAlong relative", start: 50, move: func(c *SliceCursor[int]) { c.JumpAlong([]int{1, 2, -3}, true) }, want: []int{51, 53, 50}},
+		{name: "JumpAlong single", start: 50, move: func(c *SliceCursor[int]) { c.JumpAlong(7, false) }, want: []int{7}},
+		{name: "WalkAlong absolute", start: 50, move: func(c *SliceCursor[int]) { c.WalkAlong([]int{53, 47}, 2, false) }, want: []int{52, 53, 51, 49, 47}},
+		{name: "JumpAlong provider", move: func(c *SliceCursor[int]) {
+			n := 0
+			c.JumpAlong(func() any {
+				if n++; n > 3 {
+					return nil
+				}
+				return n * 10
+			}, false)
+		}, want: []int{10, 20, 30}},
+		{name: "JumpAlong func() *int", move: func(c *SliceCursor[int]) {
+			n := 0
+			c.JumpAlong(func() *int {
+				if n++; n > 3 {
+					return nil
+				}
+				position := n * 10
+				return &position
+			}, false)
+		}, want: []int{10, 20, 30}},
+		{name: "JumpAlong func() (int, bool)", start: 50, move: func(c *SliceCursor[int]) {
+			n := 0
+			c.JumpAlong(func() (int, bool) {
+				n++
+				return n, n <= 3
+			}, true)
+		}, want: []int{51, 53, 56}},
+		{name: "WalkAlong func() (*int, int)", move: func(c *SliceCursor[int]) {
+			steps := []int{10, 4}
+			c.WalkAlong(func() (*int, int) {
+				if len(steps) == 0 {
+					return nil, 0
+				}
+				position := steps[0]
+				steps = steps[1:]
+				return &position, position / 2
+			}, 1, false)
+		}, want: []int{5, 10, 8, 6, 4}},
+		{name: "JumpAlong func() int panic", move: func(c *SliceCursor[int]) { c.JumpAlong(func() int { return 1 }, false) }, panics: true},
+		{name: "JumpAlong func(int) int panic", move: func(c *SliceCursor[int]) { c.JumpAlong(func(i int) int { return i }, false) }, panics: true},
+		{name: "dynamic stride", start: 50, move: func(c *SliceCursor[int]) {
+			s := 0
+			c.WalkTo(70, func() int { s++; return s })
+		}, want: []int{51, 53, 56, 60, 65, 70}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewSliceCursor(data, tt.mode)
+			c.JumpTo(tt.start).Yield()
+
+			defer func() {
+				if r := recover(); (r != nil) != tt.panics {
+					t.Fatalf("panic = %v, want panic %v", r, tt.panics)
+				}
+			}()
+			tt.move(c)
+			if got := c.Yield(); !slices.Equal(got, tt.want) {
+				t.Fatalf("Yield() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlowDistance(t *testing.T) {
+	tests := []struct {
+		distance, n int
+		long        bool
+		want        int
+	}{
+		{distance: 8, n: 100, want: 8},
+		{distance: -92, n: 100, want: 8},
+		{distance: 92, n: 100, want: -8},
+		{distance: 142, n: 100, want: 42},
+		{distance: 50, n: 100, want: 50},
+		{distance: -50, n: 100, want: 50},
+		{distance: 0, n: 100, want: 0},
+		{distance: 8, n: 100, long: true, want: -92},
+		{distance: 92, n: 100, long: true, want: 92},
+		{distance: 50, n: 100, long: true, want: -50},
+		{distance: 0, n: 100, long: true, want: -100},
+		{distance: 5, n: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := FlowDistance(tt.distance, tt.n, tt.long); got != tt.want {
+			t.Errorf("FlowDistance(%d, %d, %v) = %d, want %d", tt.distance, tt.n, tt.long, got, tt.want)
+		}
+	}
+}
+
+func TestSliceCursorIsLazy(t *testing.T) {
+	c := NewSliceCursor([]int{0, 1, 2, 3, 4, 5, 6}, boundary.Panic)
+	c.Jump(5)
+	if c.Current() != 0 || c.Position() != 0 {
+		t.Fatalf("Current() = %d before Yield, want 0", c.Current())
+	}
+	if got := c.Yield(); !slices.Equal(got, []int{5}) {
+		t.Fatalf("Yield() = %v, want [5]", got)
+	}
+	if c.Current() != 5 || len(c.Yield()) != 0 {
+		t.Fatalf("Current() = %d after Yield, want 5 with nothing left to yield", c.Current())
+	}
+}
+
+func TestSliceCursorBoundaryPerMovement(t *testing.T) {
+	c := NewSliceCursor([]int{0, 1, 2}, boundary.Clamp)
+	c.JumpTo(10)
+	c.Boundary(boundary.Flow)
+	c.JumpTo(4)
+	if got := c.Yield(); !slices.Equal(got, []int{2, 1}) {
+		t.Fatalf("Yield() = %v, want [2 1]", got)
+	}
+}
+
+func TestSliceCursorEmpty(t *testing.T) {
+	var c Cursorable[int] = NewSliceCursor[int](nil, boundary.Clamp)
+	if got := c.JumpTo(3).Walk(2, 1).Yield(); len(got) != 0 {
+		t.Fatalf("Yield() = %v, want nothing", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panicking cursor over no data to panic")
+		}
+	}()
+	NewSliceCursor[int](nil, boundary.Panic).JumpTo(0).Yield()
+}
@@ -1,19 +1,28 @@
 package std
 
+import (
+	"math"
+	"slices"
+	"time"
+)
+
 // A Statistic is a kind of TemporalBuffer that -only- tracks the number of times something is hit within a window of observance.
-// For example - Every Epiphany will add an entry to the Statistic stored in std.Path{"Performance", "Epiphanies"} whenever it "materializes" into something.
+// For example - Every Epiphany will add an entry to the Statistic stored in std.Path{"Performance", "Epiphanies", "Materialize"} whenever it "materializes" into something.
 //
 // NOTE: You can still query the temporal information for the exact instant of each event, but
-// there are no guarantees (or requirements) of what data the actor associates with it.
+// there are no guarantees (or requirements) of what data the actor associates with it.  If the actor
+// -does- attach a time.Duration to a hit, it participates in Percentile, Histogram, and EWMA.
 type Statistic struct {
 	*TemporalBuffer[any]
 
 	created bool
 }
 
-func NewStatistic() *Statistic {
+// NewStatistic creates a new Statistic which observes the provided window of time.  If no window is provided, this
+// will default to atlas.ObservanceWindow
+func NewStatistic(window ...*time.Duration) *Statistic {
 	s := &Statistic{
-		TemporalBuffer: NewTemporalBuffer[any](),
+		TemporalBuffer: NewTemporalBuffer[any](window...),
 		created:        true,
 	}
 	s.TemporalBuffer.sanityPassthrough = s.sanityCheck
@@ -25,3 +34,119 @@ func (s *Statistic) sanityCheck() {
 		panic("please create a std.Statistic through std.NewStatistic()")
 	}
 }
+
+// Hit records a single hit at this moment, optionally attaching a detail to it.
+func (s *Statistic) Hit(detail ...any) {
+	var d any
+	if len(detail) > 0 {
+		d = detail[0]
+	}
+	s.Record(time.Now(), d)
+}
+
+// observed returns the hits which occurred within the window of observance.
+//
+// NOTE: The buffer may hold older hits to satisfy its observed minimum - those are not considered "within" the window.
+func (s *Statistic) observed() []instant[any] {
+	s.sanityCheck()
+	return s.LatestSince(time.Now().Add(-*s.Window))
+}
+
+// durations returns the time.Duration details attached to the hits within the window of observance.
+func (s *Statistic) durations() []time.Duration {
+	return durationsOf(s.observed())
+}
+
+// durationsOf returns the time.Duration details attached to the provided hits.
+func durationsOf(observed []instant[any]) []time.Duration {
+	out := make([]time.Duration, 0, len(observed))
+	for _, inst := range observed {
+		if d, ok := inst.Element.(time.Duration); ok {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// Count returns the number of hits within the window of observance.
+func (s *Statistic) Count() uint {
+	return uint(len(s.observed()))
+}
+
+// Rate returns the number of hits per second within the window of observance.
+func (s *Statistic) Rate() float64 {
+	return s.rateOf(s.Count())
+}
+
+// rateOf returns the provided number of hits per second within the window of observance.
+func (s *Statistic) rateOf(count uint) float64 {
+	s.sanityCheck()
+	window := s.Window.Seconds()
+	if window <= 0 {
+		return 0
+	}
+	return float64(count) / window
+}
+
+// Percentile returns the attached duration which 'p' percent of the observed durations fall at or below, linearly
+// interpolating between the two closest ranks.  For instance, a 'p' of 99 yields the "p99" duration.
+//
+// NOTE: If no durations have been observed, this returns 0.
+func (s *Statistic) Percentile(p float64) time.Duration {
+	durations := s.durations()
+	slices.Sort(durations)
+	return percentileOf(durations, p)
+}
+
+// percentileOf returns the duration which 'p' percent of the provided ascending durations fall at or below.
+func percentileOf(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	p = math.Max(0, math.Min(100, p))
+	rank := p / 100 * float64(len(durations)-1)
+	low := int(math.Floor(rank))
+	high := int(math.Ceil(rank))
+	fraction := rank - float64(low)
+	return durations[low] + time.Duration(fraction*float64(durations[high]-durations[low]))
+}
+
+// Histogram buckets the observed durations by the provided ascending bounds.  Bucket 𝑖 counts the durations which are
+// at or below bounds[𝑖] (and above bounds[𝑖-1]), while a final bucket counts the durations which exceed every bound.
+//
+// NOTE: The result always holds len(bounds)+1 buckets.
+func (s *Statistic) Histogram(bounds ...time.Duration) []uint {
+	out := make([]uint, len(bounds)+1)
+	for _, d := range s.durations() {
+		i, _ := slices.BinarySearch(bounds, d)
+		out[i]++
+	}
+	return out
+}
+
+// EWMA returns the exponentially weighted moving average of the observed durations, where a duration's influence
+// halves for every 'halfLife' that has passed since it was hit.
+//
+// NOTE: If no durations have been observed, this returns 0.
+func (s *Statistic) EWMA(halfLife time.Duration) time.Duration {
+	s.sanityCheck()
+	now := time.Now()
+
+	var weighted, total float64
+	for _, inst := range s.observed() {
+		d, ok := inst.Element.(time.Duration)
+		if !ok {
+			continue
+		}
+		weight := 1.0
+		if halfLife > 0 {
+			weight = math.Exp2(-float64(now.Sub(inst.Moment)) / float64(halfLife))
+		}
+		weighted += weight * float64(d)
+		total += weight
+	}
+	if total == 0 {
+		return 0
+	}
+	return time.Duration(weighted / total)
+}
@@ -0,0 +1,100 @@
+package std
+
+import (
+	"testing"
+	"time"
+)
+
+// hundredHits creates a Statistic holding hits of 1ms through 100ms, plus a single hit with no duration attached.
+func hundredHits() *Statistic {
+	window := time.Hour
+	s := NewStatistic(&window)
+	for i := 1; i <= 100; i++ {
+		s.Hit(time.Duration(i) * time.Millisecond)
+	}
+	s.Hit()
+	return s
+}
+
+func TestStatisticCount(t *testing.T) {
+	s := hundredHits()
+	if count := s.Count(); count != 101 {
+		t.Errorf("counted %d hits, want 101", count)
+	}
+	if rate, want := s.Rate(), 101/time.Hour.Seconds(); rate != want {
+		t.Errorf("rate %v, want %v", rate, want)
+	}
+
+	// Hits kept only to satisfy the observed minimum fall outside the window
+	window := time.Second
+	minimum := uint(10)
+	old := NewStatistic(&window)
+	old.Minimum = &minimum
+	old.Record(time.Now().Add(-time.Minute), time.Millisecond)
+	old.Hit()
+	if count := old.Count(); count != 1 {
+		t.Errorf("counted %d hits within the window, want 1", count)
+	}
+}
+
+func TestStatisticPercentile(t *testing.T) {
+	s := hundredHits()
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, time.Millisecond},
+		{50, 50500 * time.Microsecond},
+		{99, 99010 * time.Microsecond},
+		{100, 100 * time.Millisecond},
+		{-5, time.Millisecond},
+		{500, 100 * time.Millisecond},
+	}
+	for _, test := range tests {
+		if got := s.Percentile(test.p); got != test.want {
+			t.Errorf("Percentile(%v) = %v, want %v", test.p, got, test.want)
+		}
+	}
+
+	window := time.Hour
+	if got := NewStatistic(&window).Percentile(50); got != 0 {
+		t.Errorf("Percentile of no durations = %v, want 0", got)
+	}
+}
+
+func TestStatisticHistogram(t *testing.T) {
+	s := hundredHits()
+	got := s.Histogram(10*time.Millisecond, 50*time.Millisecond)
+	want := []uint{10, 40, 50}
+	if len(got) != len(want) {
+		t.Fatalf("histogram %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("histogram %v, want %v", got, want)
+		}
+	}
+	if got := s.Histogram(); len(got) != 1 || got[0] != 100 {
+		t.Errorf("unbounded histogram %v, want [100]", got)
+	}
+}
+
+func TestStatisticEWMA(t *testing.T) {
+	window := time.Hour
+	s := NewStatistic(&window)
+	if got := s.EWMA(time.Second); got != 0 {
+		t.Errorf("EWMA of no durations = %v, want 0", got)
+	}
+
+	now := time.Now()
+	s.Record(now.Add(-time.Hour/2), 300*time.Millisecond)
+	s.Record(now, 0*time.Millisecond)
+	if got := s.EWMA(0); got != 150*time.Millisecond {
+		t.Errorf("EWMA without a half life = %v, want the plain mean of 150ms", got)
+	}
+
+	// The older hit is thirty half lives old, so it should carry practically no weight
+	if got := s.EWMA(time.Minute); got > time.Microsecond {
+		t.Errorf("EWMA with a one minute half life = %v, want roughly 0", got)
+	}
+}
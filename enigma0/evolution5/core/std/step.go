@@ -5,3 +5,8 @@ type Step struct {
 	Data any
 	Code any
 }
+
+// String outputs the Step's data as a string, minus any code information.
+func (s Step) String() string {
+	return Stringify(s.Data)
+}
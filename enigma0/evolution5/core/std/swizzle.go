@@ -0,0 +1,159 @@
+package std
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Swizzle builds a tuple of the target's members, one for each provided selector.  String selectors follow the
+// swizzle pattern described in Parselcode:
+//
+// - "X" yields the field named X - or, if X is a method, a function bound to the target
+//
+// - "HasFocus()" invokes the method named HasFocus and yields its result - methods must take no arguments and may
+// optionally yield an error alongside their result
+//
+// - "[0]" yields the element at index 0 (or key "0") of a slice, array, or map
+//
+// A Path selector is walked relative to the target using Locate, and any other selector isn't a member of the target
+// at all - so it's yielded as is.  For example:
+//
+//	values, err := std.Swizzle(w, "X", "Y", "HasFocus()")
+//
+//	values, err := std.Swizzle(pixel, "R", "G", "B", w.HasFocus())
+//
+// NOTE: If a string names both a member of the target and something else, the target's member is always selected.
+func Swizzle(target any, selectors ...any) ([]any, error) {
+	out := make([]any, len(selectors))
+	for i, selector := range selectors {
+		value, err := swizzle(target, selector)
+		if err != nil {
+			return nil, fmt.Errorf("selector %d (%v) could not be swizzled: %w", i, selector, err)
+		}
+		out[i] = value
+	}
+	return out, nil
+}
+
+func swizzle(target any, selector any) (any, error) {
+	switch typed := selector.(type) {
+	case Path:
+		return Locate(target, typed)
+	case string:
+	default:
+		return selector, nil
+	}
+
+	s := strings.TrimSpace(selector.(string))
+	switch {
+	case strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]"):
+		return Locate(target, Path{strings.TrimSpace(s[1 : len(s)-1])})
+	case strings.HasSuffix(s, "()"):
+		method, ok := swizzleMethod(target, strings.TrimSpace(strings.TrimSuffix(s, "()")))
+		if !ok {
+			return nil, fmt.Errorf("%T has no method named '%s'", target, s)
+		}
+		result, err := callMethod(method)
+		if err != nil || !result.IsValid() {
+			return nil, err
+		}
+		return result.Interface(), nil
+	default:
+		if method, ok := swizzleMethod(target, s); ok {
+			return method.Interface(), nil
+		}
+		return Locate(target, Path{s})
+	}
+}
+
+// swizzleMethod finds the named method of the target - including those with pointer receivers, even if the target
+// was provided by value.
+func swizzleMethod(target any, name string) (reflect.Value, bool) {
+	v := reflect.ValueOf(target)
+	for v.IsValid() && v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return reflect.Value{}, false
+	}
+	if method, ok := methodOf(v, name); ok {
+		return method, true
+	}
+	if v.Kind() != reflect.Pointer {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		return methodOf(ptr, name)
+	}
+	return reflect.Value{}, false
+}
+
+// swizzled asserts a swizzled value into the requested type, treating nil as its zero value.
+func swizzled[T any](value any, position int) (T, error) {
+	var zero T
+	if value == nil {
+		return zero, nil
+	}
+	typed, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("selector %d yielded a %T, not a %v", position, value, reflect.TypeFor[T]())
+	}
+	return typed, nil
+}
+
+// Swizzle2 swizzles two members of the target into their typed forms - see Swizzle.
+func Swizzle2[A, B any](target any, a, b any) (A, B, error) {
+	var outA A
+	var outB B
+	values, err := Swizzle(target, a, b)
+	if err != nil {
+		return outA, outB, err
+	}
+	if outA, err = swizzled[A](values[0], 0); err != nil {
+		return outA, outB, err
+	}
+	outB, err = swizzled[B](values[1], 1)
+	return outA, outB, err
+}
+
+// Swizzle3 swizzles three members of the target into their typed forms - see Swizzle.
+func Swizzle3[A, B, C any](target any, a, b, c any) (A, B, C, error) {
+	var outA A
+	var outB B
+	var outC C
+	values, err := Swizzle(target, a, b, c)
+	if err != nil {
+		return outA, outB, outC, err
+	}
+	if outA, err = swizzled[A](values[0], 0); err != nil {
+		return outA, outB, outC, err
+	}
+	if outB, err = swizzled[B](values[1], 1); err != nil {
+		return outA, outB, outC, err
+	}
+	outC, err = swizzled[C](values[2], 2)
+	return outA, outB, outC, err
+}
+
+// Swizzle4 swizzles four members of the target into their typed forms - see Swizzle.
+func Swizzle4[A, B, C, D any](target any, a, b, c, d any) (A, B, C, D, error) {
+	var outA A
+	var outB B
+	var outC C
+	var outD D
+	values, err := Swizzle(target, a, b, c, d)
+	if err != nil {
+		return outA, outB, outC, outD, err
+	}
+	if outA, err = swizzled[A](values[0], 0); err != nil {
+		return outA, outB, outC, outD, err
+	}
+	if outB, err = swizzled[B](values[1], 1); err != nil {
+		return outA, outB, outC, outD, err
+	}
+	if outC, err = swizzled[C](values[2], 2); err != nil {
+		return outA, outB, outC, outD, err
+	}
+	outD, err = swizzled[D](values[3], 3)
+	return outA, outB, outC, outD, err
+}
@@ -0,0 +1,55 @@
+package std
+
+import (
+	"strings"
+	"testing"
+)
+
+type swizzlee struct {
+	X, Y, Z, W int
+}
+
+func (s swizzlee) Sum() int { return s.X + s.Y + s.Z + s.W }
+
+func TestSwizzleTyped(t *testing.T) {
+	target := swizzlee{X: 1, Y: 2, Z: 3, W: 4}
+
+	x, y, err := Swizzle2[int, int](target, "X", "Y")
+	if err != nil || x != 1 || y != 2 {
+		t.Fatalf("Swizzle2 = %v, %v, %v", x, y, err)
+	}
+	x, y, z, err := Swizzle3[int, int, int](target, "X", "Y", "Z")
+	if err != nil || x != 1 || y != 2 || z != 3 {
+		t.Fatalf("Swizzle3 = %v, %v, %v, %v", x, y, z, err)
+	}
+	x, y, z, sum, err := Swizzle4[int, int, int, int](target, "X", "Y", "Z", "Sum()")
+	if err != nil || x != 1 || y != 2 || z != 3 || sum != 10 {
+		t.Fatalf("Swizzle4 = %v, %v, %v, %v, %v", x, y, z, sum, err)
+	}
+}
+
+func TestSwizzleTypedErrorPosition(t *testing.T) {
+	target := swizzlee{}
+
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"Swizzle3 missing", errOf3(Swizzle3[int, int, int](target, "X", "Y", "Missing")), "selector 2"},
+		{"Swizzle3 mistyped", errOf3(Swizzle3[int, int, string](target, "X", "Y", "Z")), "selector 2"},
+		{"Swizzle4 missing", errOf4(Swizzle4[int, int, int, int](target, "X", "Y", "Z", "Missing")), "selector 3"},
+		{"Swizzle4 mistyped", errOf4(Swizzle4[int, int, int, string](target, "X", "Y", "Z", "W")), "selector 3"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.err == nil || !strings.Contains(test.err.Error(), test.want) {
+				t.Fatalf("error = %v, want it to mention %q", test.err, test.want)
+			}
+		})
+	}
+}
+
+func errOf3[A, B, C any](_ A, _ B, _ C, err error) error { return err }
+
+func errOf4[A, B, C, D any](_ A, _ B, _ C, _ D, err error) error { return err }
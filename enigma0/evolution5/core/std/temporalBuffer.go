@@ -20,13 +20,46 @@ import (
 // 2 - You are not required to add elements temporally sequentially - instead, you provide the moment to add
 //
 // 3 - Temporal buffers automatically trim on access
+//
+// 4 - You can Subscribe to newly recorded instants, or observe the Evictions of trimmed instants, rather than polling
+//
+// 5 - Retention can be further bounded by count or size, and trimmed instants can be downsampled into a History
 type TemporalBuffer[T any] struct {
-	buffer []instant[T]
+	buffer  *temporalRing[T]
+	history []instant[T]
 
+	// Window defines the period of time this buffer observes.
 	Window *time.Duration
 
-	master sync.Mutex
-	
+	// Limit caps the number of instants held, regardless of the observance window.  A zero value is unbounded.
+	//
+	// NOTE: This also caps the number of aggregates held in the History.
+	Limit uint
+
+	// Budget caps the approximate number of bytes held, as measured by Size.  A zero value is unbounded.
+	Budget uint
+
+	// Size measures the approximate number of bytes an element occupies.  If nil, the static size of T is used.
+	//
+	// NOTE: Each element is measured once as it's recorded and once as it's trimmed, so please set this before recording.
+	Size func(T) uint
+
+	// Minimum overrides atlas.ObservedMinimum as the number of instants to always keep, regardless of age, Limit, or Budget.
+	Minimum *uint
+
+	// Downsample, if set, merges trimmed instants into aggregates rather than dropping them - see History.
+	Downsample func([]instant[T]) T
+
+	// Resolution defines the period of time each downsampled aggregate spans.  If nil, the observance Window is used.
+	Resolution *time.Duration
+
+	// bytes holds the running total of every held element's Size.
+	bytes uint
+
+	master      sync.Mutex
+	subscribers []*temporalSubscription[T]
+	evictors    []*temporalSubscription[T]
+
 	sanityPassthrough func()
 }
 
@@ -38,7 +71,7 @@ func NewTemporalBuffer[T any](window ...*time.Duration) *TemporalBuffer[T] {
 		w = window[0]
 	}
 	return &TemporalBuffer[T]{
-		buffer: make([]instant[T], 0),
+		buffer: newTemporalRing[T](),
 		Window: w,
 	}
 }
@@ -55,65 +88,68 @@ func (b *TemporalBuffer[T]) sanityCheck() {
 	if b.Window == nil {
 		b.Window = &atlas.ObservanceWindow
 	}
-	
+
 	if b.sanityPassthrough != nil {
 		b.sanityPassthrough()
 	}
 }
 
-func (b *TemporalBuffer[T]) trim() {
+// trim removes any instants which have fallen outside the retention policies and returns them.
+func (b *TemporalBuffer[T]) trim() []instant[T] {
 	b.sanityCheck()
-	now := time.Now()
-	cutoff := now.Add(-*b.Window)
 
-	var i int
-	for _, inst := range b.buffer {
-		if inst.Moment.After(cutoff) {
-			break
-		}
-		i++
-	}
-	maximum := len(b.buffer) - int(atlas.ObservedMinimum)
-	if maximum < 0 {
-		maximum = 0
+	evicted := b.buffer.shift(b.expired())
+	for _, inst := range evicted {
+		b.bytes -= min(b.bytes, b.sizeOf(inst.Element))
 	}
-	if i > maximum {
-		i = maximum
+
+	if b.Downsample != nil {
+		b.downsample(evicted)
 	}
+	return evicted
+}
+
+// access trims the buffer before calling the provided function while holding the master lock, then announces
+// any evicted instants once the lock has been released.
+func (b *TemporalBuffer[T]) access(fn func()) {
+	b.sanityCheck()
+	b.master.Lock()
+
+	evicted := b.trim()
+	evictors := snapshot(b.evictors)
+	fn()
+	b.master.Unlock()
 
-	b.buffer = b.buffer[i:]
+	publish(evictors, evicted...)
 }
 
+// Len returns the number of instants currently held in the buffer.
 func (b *TemporalBuffer[T]) Len() uint {
-	b.sanityCheck()
-	return uint(len(b.buffer))
+	var n int
+	b.access(func() {
+		n = b.buffer.len()
+	})
+	return uint(n)
 }
 
 // LatestSince will grab the latest elements after the provided moment in time, exclusively.  If you'd like to include the
 // requested moment, you can set the optional 'includeMoment' parameter to true.  When performing integration and
 // differentiation, you'll want to include the moment - otherwise, it makes more sense to exclude it if simply recording
 // temporal signal data.
+//
+// NOTE: "Including" the moment yields the latest element at or before it, so the result remains continuous.
 func (b *TemporalBuffer[T]) LatestSince(moment time.Time, includeMoment ...bool) []instant[T] {
-	b.sanityCheck()
 	include := len(includeMoment) > 0 && includeMoment[0]
 
-	out := b.Yield()
-	point := -1
-	included := false
-	for i := len(out) - 1; i >= 0; i-- {
-		if out[i].Moment.After(moment) {
-			point = i
-		} else if include && !included {
-			included = true
-			point = i
-		} else {
-			break
+	var out []instant[T]
+	b.access(func() {
+		point := b.buffer.after(moment)
+		if include && point > 0 {
+			point--
 		}
-	}
-	if point == -1 {
-		return []instant[T]{}
-	}
-	return out[point:]
+		out = b.buffer.slice(point, b.buffer.len())
+	})
+	return out
 }
 
 // Latest returns the most recent elements in logical temporal order up to the provided depth.  If a negative depth is provided, all elements are returned.
@@ -124,64 +160,69 @@ func (b *TemporalBuffer[T]) Latest(depth ...int) []instant[T] {
 		d = depth[0]
 	}
 
-	b.sanityCheck()
-	out := b.Yield()
-	if d <= 0 {
-		return out
-	}
-	end := len(out) - d
-	if end < 0 {
-		end = 0
-	}
-	out = out[end:]
-	if len(out) > d {
-		panic("too many elements")
+	var out []instant[T]
+	b.access(func() {
+		start := 0
+		if d > 0 {
+			start = b.buffer.len() - d
+		}
+		out = b.buffer.slice(start, b.buffer.len())
+	})
+	return out
+}
+
+// Between returns the elements recorded within the provided moments, inclusively, in logical temporal order.
+//
+// NOTE: Only the requested range is copied out of the buffer.
+func (b *TemporalBuffer[T]) Between(from, to time.Time) []instant[T] {
+	if to.Before(from) {
+		from, to = to, from
 	}
+
+	var out []instant[T]
+	b.access(func() {
+		out = b.buffer.slice(b.buffer.notBefore(from), b.buffer.after(to))
+	})
 	return out
 }
 
+// Yield returns a thread-independent copy of the entire buffer in logical temporal order.
 func (b *TemporalBuffer[T]) Yield() []instant[T] {
-	b.sanityCheck()
-	b.master.Lock()
-	defer b.master.Unlock()
-
-	b.trim()
-	out := make([]instant[T], len(b.buffer))
-	copy(out, b.buffer)
+	var out []instant[T]
+	b.access(func() {
+		out = b.buffer.slice(0, b.buffer.len())
+	})
 	return out
 }
 
+// Record places the element into the buffer at the provided moment in time.
+//
+// NOTE: The moment is found through a binary search, and elements sharing a moment retain the order they were recorded in.
 func (b *TemporalBuffer[T]) Record(moment time.Time, element T) {
 	b.sanityCheck()
 	b.master.Lock()
-	defer b.master.Unlock()
 
-	t := len(b.buffer) - 1
-	for i := len(b.buffer) - 1; i >= 0; i-- {
-		if b.buffer[i].Moment.Before(moment) {
-			break
-		}
-		t--
-	}
-	t++
-	if t < 0 {
-		t = 0
-	}
-	left := b.buffer[:t]
-	right := b.buffer[t:]
-	left = append(left, instant[T]{moment, element})
-	left = append(left, right...)
-	b.buffer = left
+	inst := instant[T]{moment, element}
+	b.buffer.insert(inst)
+	b.bytes += b.sizeOf(element)
+
+	evicted := b.trim()
+	subscribers := snapshot(b.subscribers)
+	evictors := snapshot(b.evictors)
+	b.master.Unlock()
 
-	b.trim()
+	publish(subscribers, inst)
+	publish(evictors, evicted...)
 }
 
 // Calculate will yield the Latest(depth) - then, for each of the elements from oldest to newest, call calcFn(dt, element)
 // before returning the accumulated results.
+//
+// NOTE: The 'dt' value is the true amount of time between each element and the one before it.  The oldest element has no
+// predecessor to measure against, so it's always provided a negative dt - allowing you to "seed" your calculation from it.
 func (b *TemporalBuffer[T]) Calculate(depth int, calcFn func(time.Duration, T) any) []instant[any] {
 	b.sanityCheck()
-	//yield := b.Latest(depth)
-	return nil
+	return calculate(b.Latest(depth), calcFn)
 }
 
 // CalculateSince will yield the LatestSince(moment, includeMoment) - then, for each of the elements from oldest to newest, call
@@ -190,8 +231,23 @@ func (b *TemporalBuffer[T]) Calculate(depth int, calcFn func(time.Duration, T) a
 // NOTE: For integration and differentiation, you'll want to include the provided moment in the calculation to create a continuous calculation =)
 func (b *TemporalBuffer[T]) CalculateSince(moment time.Time, calcFn func(time.Duration, T) any, includeMoment ...bool) []instant[any] {
 	b.sanityCheck()
-	//yield := b.LatestSince(moment)
-	return nil
+	return calculate(b.LatestSince(moment, includeMoment...), calcFn)
+}
+
+// calculate folds calcFn across the provided temporally ordered instants, stamping each result with its source moment.
+func calculate[T any](yield []instant[T], calcFn func(time.Duration, T) any) []instant[any] {
+	out := make([]instant[any], len(yield))
+	for i, inst := range yield {
+		dt := time.Duration(-1)
+		if i > 0 {
+			dt = inst.Moment.Sub(yield[i-1].Moment)
+		}
+		out[i] = instant[any]{
+			Moment:  inst.Moment,
+			Element: calcFn(dt, inst.Element),
+		}
+	}
+	return out
 }
 
 // Integrate will perform standard temporal integration against the provided depth of elements.  This will yield the area
@@ -203,11 +259,28 @@ func (b *TemporalBuffer[T]) CalculateSince(moment time.Time, calcFn func(time.Du
 func (b *TemporalBuffer[T]) Integrate(base uint16, depth int, parseFn ...func(T) any) ([]instant[any], float64) {
 	return b.IntegrateTolerance(base, depth, atlas.Precision, parseFn...)
 }
+
+// IntegrateTolerance performs trapezoidal integration against the provided depth of elements.  The area of each
+// slice is measured in "units × seconds" and stamped with the moment that closes it - the oldest element only
+// opens the first slice, so it always yields an area of zero.
 func (b *TemporalBuffer[T]) IntegrateTolerance(base uint16, depth int, precision uint, parseFn ...func(T) any) ([]instant[any], float64) {
 	b.sanityCheck()
+	return integrate(b.Latest(depth), parseFn...)
+}
+
+// IntegrateSince performs trapezoidal integration against the elements since the provided moment.
+//
+// NOTE: This is INCLUSIVE of the provided moment =)
+func (b *TemporalBuffer[T]) IntegrateSince(moment time.Time, parseFn ...func(T) any) ([]instant[any], float64) {
+	b.sanityCheck()
+	return integrate(b.LatestSince(moment, true), parseFn...)
+}
+
+// integrate performs trapezoidal integration across the provided temporally ordered instants.
+func integrate[T any](yield []instant[T], parseFn ...func(T) any) ([]instant[any], float64) {
 	area := 0.0
-	last := 0.0
-	return b.Calculate(depth, func(dt time.Duration, element T) any {
+	var previous any
+	slices := calculate(yield, func(dt time.Duration, element T) any {
 		var number any
 		if len(parseFn) > 0 {
 			number = parseFn[0](element)
@@ -215,28 +288,99 @@ func (b *TemporalBuffer[T]) IntegrateTolerance(base uint16, depth int, precision
 			number = element
 		}
 		if dt < 0 {
-			last = 0.0
+			previous = number
 			return 0.0
 		}
-		last = tiny.ParseInto[float64](tiny.Multiply(dt, tiny.Add(number, last), 0.5))
-		area = tiny.ParseInto[float64](tiny.Add(area, last))
-		return last
-	}), area
+		slice := tiny.ParseInto[float64](tiny.Multiply(dt.Seconds(), tiny.Add(number, previous), 0.5))
+		previous = number
+		area = tiny.ParseInto[float64](tiny.Add(area, slice))
+		return slice
+	})
+	return slices, area
 }
 
-func (b *TemporalBuffer[T]) IntegrateSince(moment time.Time, parseFn ...func(T) any) []instant[T] {
-	b.sanityCheck()
-	// NOTE: This is INCLUSIVE of the provided moment =)
-	return nil
+// Differentiate will perform first-order temporal differentiation against the provided depth of elements.  This will
+// yield the rate of change (in "units per second") at every moment.  To differentiate to a higher order, please use
+// DifferentiateOrder.
+//
+// NOTE: If the elements are NOT implicitly parseable, this will panic.  In that case, please provide a 'parseFn'
+// which translates the buffered information into a parseable type.  A parseable type is any numeric, string, or function provider type.
+func (b *TemporalBuffer[T]) Differentiate(depth int, parseFn ...func(T) any) []instant[any] {
+	return b.DifferentiateOrder(1, depth, parseFn...)
 }
 
-func (b *TemporalBuffer[T]) Differentiate(depth int, parseFn ...func(T) any) []instant[T] {
+// DifferentiateOrder will repeatedly differentiate the provided depth of elements until reaching the requested order.
+//
+// NOTE: An order of 0 simply yields the parsed elements.
+func (b *TemporalBuffer[T]) DifferentiateOrder(order uint, depth int, parseFn ...func(T) any) []instant[any] {
 	b.sanityCheck()
-	return nil
+	return differentiate(b.Latest(depth), order, parseFn...)
 }
 
-func (b *TemporalBuffer[T]) DifferentiateSince(moment time.Time, parseFn ...func(T) any) []instant[T] {
+// DifferentiateSince will perform first-order temporal differentiation against the elements since the provided moment.
+//
+// NOTE: This is INCLUSIVE of the provided moment =)
+func (b *TemporalBuffer[T]) DifferentiateSince(moment time.Time, parseFn ...func(T) any) []instant[any] {
 	b.sanityCheck()
-	// NOTE: This is INCLUSIVE of the provided moment =)
-	return nil
+	return differentiate(b.LatestSince(moment, true), 1, parseFn...)
+}
+
+// differentiate performs finite-difference differentiation across the provided temporally ordered instants.
+//
+// Because elements can be recorded at any moment, the samples are rarely evenly spaced.  Interior points use the
+// three-point difference weighted by the spacing on either side, while the two endpoints fall back to a one-sided
+// difference.  Coincident moments have no spacing to measure against, so they borrow the neighbor's rate instead.
+func differentiate[T any](yield []instant[T], order uint, parseFn ...func(T) any) []instant[any] {
+	if len(yield) < 2 && order > 0 {
+		return []instant[any]{}
+	}
+
+	moments := make([]time.Time, len(yield))
+	values := make([]float64, len(yield))
+	for i, inst := range yield {
+		var number any
+		if len(parseFn) > 0 {
+			number = parseFn[0](inst.Element)
+		} else {
+			number = inst.Element
+		}
+		moments[i] = inst.Moment
+		values[i] = tiny.ParseInto[float64](number)
+	}
+
+	for ; order > 0; order-- {
+		rates := make([]float64, len(values))
+		for i := range values {
+			var back, forward float64
+			if i > 0 {
+				back = moments[i].Sub(moments[i-1]).Seconds()
+			}
+			if i < len(values)-1 {
+				forward = moments[i+1].Sub(moments[i]).Seconds()
+			}
+
+			switch {
+			case back > 0 && forward > 0:
+				rates[i] = -forward/(back*(back+forward))*values[i-1] +
+					(forward-back)/(back*forward)*values[i] +
+					back/(forward*(back+forward))*values[i+1]
+			case back > 0:
+				rates[i] = (values[i] - values[i-1]) / back
+			case forward > 0:
+				rates[i] = (values[i+1] - values[i]) / forward
+			case i > 0:
+				rates[i] = rates[i-1]
+			}
+		}
+		values = rates
+	}
+
+	out := make([]instant[any], len(values))
+	for i, value := range values {
+		out[i] = instant[any]{
+			Moment:  moments[i],
+			Element: value,
+		}
+	}
+	return out
 }
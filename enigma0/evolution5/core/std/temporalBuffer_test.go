@@ -0,0 +1,274 @@
+package std
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+// recorded creates a buffer holding each element at its offset from the provided moment, in the order given.
+func recorded(now time.Time, offsets []time.Duration, elements []float64) *TemporalBuffer[float64] {
+	window := time.Hour
+	b := NewTemporalBuffer[float64](&window)
+	for i, offset := range offsets {
+		b.Record(now.Add(offset), elements[i])
+	}
+	return b
+}
+
+func TestTemporalBufferCalculate(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		offsets  []time.Duration
+		elements []float64
+		depth    int
+		wantDt   []time.Duration
+		wantEl   []float64
+	}{
+		{
+			name:   "empty",
+			depth:  -1,
+			wantDt: []time.Duration{},
+			wantEl: []float64{},
+		},
+		{
+			name:     "single element seeds with a negative dt",
+			offsets:  []time.Duration{-time.Second},
+			elements: []float64{7},
+			depth:    -1,
+			wantDt:   []time.Duration{-1},
+			wantEl:   []float64{7},
+		},
+		{
+			name:     "irregular and in order",
+			offsets:  []time.Duration{-3 * time.Second, -2500 * time.Millisecond, -time.Second, -10 * time.Millisecond},
+			elements: []float64{1, 2, 3, 4},
+			depth:    -1,
+			wantDt:   []time.Duration{-1, 500 * time.Millisecond, 1500 * time.Millisecond, 990 * time.Millisecond},
+			wantEl:   []float64{1, 2, 3, 4},
+		},
+		{
+			name:     "irregular and out of order",
+			offsets:  []time.Duration{-time.Second, -3 * time.Second, -10 * time.Millisecond, -2500 * time.Millisecond},
+			elements: []float64{3, 1, 4, 2},
+			depth:    -1,
+			wantDt:   []time.Duration{-1, 500 * time.Millisecond, 1500 * time.Millisecond, 990 * time.Millisecond},
+			wantEl:   []float64{1, 2, 3, 4},
+		},
+		{
+			name:     "depth reseeds from the oldest element within it",
+			offsets:  []time.Duration{-time.Second, -3 * time.Second, -10 * time.Millisecond, -2500 * time.Millisecond},
+			elements: []float64{3, 1, 4, 2},
+			depth:    2,
+			wantDt:   []time.Duration{-1, 990 * time.Millisecond},
+			wantEl:   []float64{3, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := recorded(now, tt.offsets, tt.elements)
+
+			var dts []time.Duration
+			var elements []float64
+			out := b.Calculate(tt.depth, func(dt time.Duration, element float64) any {
+				dts = append(dts, dt)
+				elements = append(elements, element)
+				return element * 2
+			})
+
+			if !slices.Equal(dts, tt.wantDt) || !slices.Equal(elements, tt.wantEl) {
+				t.Fatalf("calcFn saw dt %v and elements %v, want %v and %v", dts, elements, tt.wantDt, tt.wantEl)
+			}
+			yield := b.Latest(tt.depth)
+			if len(out) != len(yield) {
+				t.Fatalf("Calculate yielded %d results, want %d", len(out), len(yield))
+			}
+			for i := range out {
+				if !out[i].Moment.Equal(yield[i].Moment) || out[i].Element != yield[i].Element*2 {
+					t.Fatalf("result %d = %v, want %v at %v", i, out[i].Element, yield[i].Element*2, yield[i].Moment)
+				}
+			}
+		})
+	}
+}
+
+func TestTemporalBufferCalculateSince(t *testing.T) {
+	now := time.Now()
+	offsets := []time.Duration{-time.Second, -4 * time.Second, -2 * time.Second, -3 * time.Second}
+	b := recorded(now, offsets, []float64{4, 1, 3, 2})
+
+	tests := []struct {
+		name    string
+		since   time.Duration
+		include bool
+		wantDt  []time.Duration
+		wantEl  []float64
+	}{
+		{name: "exclusive of a recorded moment", since: -3 * time.Second, wantDt: []time.Duration{-1, time.Second}, wantEl: []float64{3, 4}},
+		{name: "inclusive of a recorded moment", since: -3 * time.Second, include: true, wantDt: []time.Duration{-1, time.Second, time.Second}, wantEl: []float64{2, 3, 4}},
+		{name: "inclusive between moments", since: -2500 * time.Millisecond, include: true, wantDt: []time.Duration{-1, time.Second, time.Second}, wantEl: []float64{2, 3, 4}},
+		{name: "after everything", since: 0, include: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dts []time.Duration
+			var elements []float64
+			b.CalculateSince(now.Add(tt.since), func(dt time.Duration, element float64) any {
+				dts = append(dts, dt)
+				elements = append(elements, element)
+				return nil
+			}, tt.include)
+
+			if !slices.Equal(dts, tt.wantDt) || !slices.Equal(elements, tt.wantEl) {
+				t.Fatalf("calcFn saw dt %v and elements %v, want %v and %v", dts, elements, tt.wantDt, tt.wantEl)
+			}
+		})
+	}
+}
+
+func TestTemporalBufferIntegrate(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		offsets  []time.Duration
+		elements []float64
+		wantArea float64
+		want     []float64
+	}{
+		{
+			name:     "single element has no area",
+			offsets:  []time.Duration{-time.Second},
+			elements: []float64{5},
+			want:     []float64{0},
+		},
+		{
+			name:     "constant signal",
+			offsets:  []time.Duration{-3 * time.Second, -time.Second, -2 * time.Second},
+			elements: []float64{2, 2, 2},
+			wantArea: 4,
+			want:     []float64{0, 2, 2},
+		},
+		{
+			name:     "irregular and out of order",
+			offsets:  []time.Duration{-3 * time.Second, -time.Second, -2500 * time.Millisecond},
+			elements: []float64{2, 2, 4},
+			// 0.5s × (2+4)/2 = 1.5, then 1.5s × (4+2)/2 = 4.5
+			wantArea: 6,
+			want:     []float64{0, 1.5, 4.5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := recorded(now, tt.offsets, tt.elements)
+			slices, area := b.Integrate(10, -1)
+			if math.Abs(area-tt.wantArea) > 1e-9 {
+				t.Fatalf("area = %v, want %v", area, tt.wantArea)
+			}
+			if len(slices) != len(tt.want) {
+				t.Fatalf("yielded %d slices, want %d", len(slices), len(tt.want))
+			}
+			for i, slice := range slices {
+				if math.Abs(slice.Element.(float64)-tt.want[i]) > 1e-9 {
+					t.Fatalf("slice %d = %v, want %v", i, slice.Element, tt.want[i])
+				}
+			}
+		})
+	}
+
+	b := recorded(now, tests[2].offsets, tests[2].elements)
+	if _, area := b.IntegrateSince(now.Add(-2 * time.Second)); math.Abs(area-4.5) > 1e-9 {
+		t.Fatalf("IntegrateSince area = %v, want 4.5", area)
+	}
+}
+
+func TestTemporalBufferDifferentiate(t *testing.T) {
+	base := time.Now().Add(-time.Minute)
+	seconds := func(xs ...float64) []time.Duration {
+		offsets := make([]time.Duration, len(xs))
+		for i, x := range xs {
+			offsets[i] = time.Duration(x * float64(time.Second))
+		}
+		return offsets
+	}
+	tests := []struct {
+		name     string
+		xs       []float64
+		elements []float64
+		order    uint
+		depth    int
+		want     []float64
+	}{
+		{name: "fewer than two elements", xs: []float64{0}, elements: []float64{5}, order: 1, depth: -1, want: []float64{}},
+		{name: "order zero yields the parsed elements", xs: []float64{0, 1}, elements: []float64{3, 4}, depth: -1, want: []float64{3, 4}},
+		{
+			name:     "linear signal at irregular spacing",
+			xs:       []float64{0, 0.5, 2, 2.1, 5},
+			elements: []float64{1, 2, 5, 5.2, 11},
+			order:    1,
+			depth:    -1,
+			want:     []float64{2, 2, 2, 2, 2},
+		},
+		{
+			// The three-point difference is exact for a quadratic, while the endpoints are one-sided
+			name:     "quadratic signal at irregular spacing",
+			xs:       []float64{0, 0.3, 1, 1.1, 2.5, 4},
+			elements: []float64{0, 0.09, 1, 1.21, 6.25, 16},
+			order:    1,
+			depth:    -1,
+			want:     []float64{0.3, 0.6, 2, 2.2, 5, 6.5},
+		},
+		{
+			name:     "recorded out of order",
+			xs:       []float64{2.5, 0, 4, 1, 0.3, 1.1},
+			elements: []float64{6.25, 0, 16, 1, 0.09, 1.21},
+			order:    1,
+			depth:    -1,
+			want:     []float64{0.3, 0.6, 2, 2.2, 5, 6.5},
+		},
+		{name: "coincident moments borrow the prior rate", xs: []float64{0, 1, 1}, elements: []float64{0, 2, 9}, order: 1, depth: -1, want: []float64{2, 2, 2}},
+		{name: "depth limits the elements", xs: []float64{0, 1, 2, 4}, elements: []float64{0, 1, 2, 8}, order: 1, depth: 2, want: []float64{3, 3}},
+		{
+			name:     "second order of a linear signal",
+			xs:       []float64{0, 0.5, 2, 2.1, 5},
+			elements: []float64{1, 2, 5, 5.2, 11},
+			order:    2,
+			depth:    -1,
+			want:     []float64{0, 0, 0, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := recorded(base, seconds(tt.xs...), tt.elements)
+			rates := b.DifferentiateOrder(tt.order, tt.depth)
+			if len(rates) != len(tt.want) {
+				t.Fatalf("yielded %d rates, want %d", len(rates), len(tt.want))
+			}
+			for i, rate := range rates {
+				if math.Abs(rate.Element.(float64)-tt.want[i]) > 1e-6 {
+					t.Fatalf("rate %d = %v, want %v", i, rate.Element, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTemporalBufferDifferentiateSince(t *testing.T) {
+	now := time.Now()
+	b := recorded(now, []time.Duration{-3 * time.Second, -2 * time.Second, -time.Second}, []float64{10, 4, 0})
+
+	rates := b.DifferentiateSince(now.Add(-2 * time.Second))
+	if len(rates) != 2 || rates[0].Element != -4.0 || rates[1].Element != -4.0 {
+		t.Fatalf("rates = %v, want the two elements from the inclusive moment at -4 per second", rates)
+	}
+
+	parsed := b.Differentiate(-1, func(element float64) any { return element / 2 })
+	if len(parsed) != 3 || parsed[0].Element != -3.0 || parsed[2].Element != -2.0 {
+		t.Fatalf("parsed rates = %v, want -3 and -2 at the endpoints", parsed)
+	}
+}
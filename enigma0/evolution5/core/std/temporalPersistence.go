@@ -0,0 +1,303 @@
+package std
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/backpressure"
+)
+
+/**
+This file holds the persistence layer of a TemporalBuffer.  A temporal file mirrors a single buffer and is very simple:
+
+	"tbuf" + version byte
+	[moment - int64 unix nanoseconds][length - uint32][element - length encoded bytes]
+	[moment - int64 unix nanoseconds][length - uint32][element - length encoded bytes]
+	...
+
+All integers are big-endian.  Snapshots replace the file wholesale, while persistence appends to it after its initial
+snapshot.  If a process dies mid-append, the trailing partial record is simply ignored on read.
+*/
+
+var temporalHeader = []byte{'t', 'b', 'u', 'f', 0}
+
+// TemporalRecordLimit caps the encoded length of a single element in a temporal file, so a corrupt length can't demand
+// an enormous allocation on read.
+var TemporalRecordLimit uint32 = 64 << 20
+
+// A Codec translates an element to and from its encoded form when persisting a TemporalBuffer.
+//
+// NOTE: If no codec is provided to the persistence functions, GobCodec is used.
+type Codec[T any] struct {
+	Encode func(T) ([]byte, error)
+	Decode func([]byte) (T, error)
+}
+
+// GobCodec creates a Codec which encodes each element independently using encoding/gob.
+func GobCodec[T any]() Codec[T] {
+	return Codec[T]{
+		Encode: func(element T) ([]byte, error) {
+			var buf bytes.Buffer
+			err := gob.NewEncoder(&buf).Encode(&element)
+			return buf.Bytes(), err
+		},
+		Decode: func(data []byte) (T, error) {
+			var element T
+			err := gob.NewDecoder(bytes.NewReader(data)).Decode(&element)
+			return element, err
+		},
+	}
+}
+
+func codecOf[T any](codec ...Codec[T]) Codec[T] {
+	if len(codec) > 0 && codec[0].Encode != nil && codec[0].Decode != nil {
+		return codec[0]
+	}
+	return GobCodec[T]()
+}
+
+// Snapshot writes the current contents of the buffer to the temporal file at the provided path, replacing anything it
+// previously held.
+//
+// NOTE: The file is written beside the path and then renamed over it, so an interrupted snapshot leaves the prior file intact.
+func (b *TemporalBuffer[T]) Snapshot(path string, codec ...Codec[T]) error {
+	return rewriteTemporalFile(path, codecOf(codec...), b.Yield()...)
+}
+
+// Persist snapshots the buffer to the temporal file at the provided path and then continues to append every
+// newly recorded instant until the context is done.  This blocks until then, so you'll typically call it
+// through a goroutine.
+//
+// NOTE: The snapshot and subscription are taken atomically, so no instant is missed or written twice.  Because of
+// that, persistence subscribes with backpressure.Block - meaning a slow disk will slow down Record.
+//
+// NOTE: Just like Snapshot, this replaces anything the file previously held - so only one buffer should write to a path.
+func (b *TemporalBuffer[T]) Persist(ctx context.Context, path string, codec ...Codec[T]) error {
+	c := codecOf(codec...)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var existing []instant[T]
+	var sub *temporalSubscription[T]
+	b.access(func() {
+		existing = b.buffer.slice(0, b.buffer.len())
+		sub = newTemporalSubscription[T](ctx, backpressure.Block)
+		b.subscribers = append(b.subscribers, sub)
+		go b.unsubscribe(sub, &b.subscribers)
+	})
+
+	if err := rewriteTemporalFile(path, c, existing...); err != nil {
+		return err
+	}
+	file, err := openTemporalFile(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	for inst := range sub.channel {
+		if err = writeInstants(file, c, inst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rehydrate records every instant found in the temporal file at the provided path into the buffer.
+//
+// NOTE: The buffer's retention policies still apply, so you may want to widen its Window before rehydrating old history.
+func (b *TemporalBuffer[T]) Rehydrate(path string, codec ...Codec[T]) error {
+	return readInstants(context.Background(), path, codecOf(codec...), func(inst instant[T]) {
+		b.Record(inst.Moment, inst.Element)
+	})
+}
+
+// Replay re-emits the instants found in the temporal file at the provided path through the returned channel, waiting
+// the originally recorded amount of time between each divided by the provided speed.  For instance, a speed of 1 replays
+// in real time while a speed of 2 replays twice as fast.  A speed of 0 or less emits every instant as fast as it's received.
+//
+// NOTE: The file's header is checked before returning, so a missing, corrupt, or foreign file returns an error immediately.
+// Any error found while decoding the rest of the file is delivered through the returned error channel, which is buffered
+// and closed alongside the instant channel once the file is exhausted or the context is done.
+func Replay[T any](ctx context.Context, path string, speed float64, codec ...Codec[T]) (<-chan instant[T], <-chan error, error) {
+	file, r, err := openInstants(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := make(chan instant[T])
+	failures := make(chan error, 1)
+	go func() {
+		defer file.Close()
+		defer close(failures)
+		defer close(out)
+
+		var last *time.Time
+		err := decodeInstants(ctx, r, codecOf(codec...), func(inst instant[T]) {
+			if last != nil && speed > 0 {
+				delay := time.Duration(float64(inst.Moment.Sub(*last)) / speed)
+				if delay > 0 {
+					select {
+					case <-time.After(delay):
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			moment := inst.Moment
+			last = &moment
+
+			select {
+			case out <- inst:
+			case <-ctx.Done():
+			}
+		})
+		if err != nil {
+			failures <- err
+		}
+	}()
+	return out, failures, nil
+}
+
+// rewriteTemporalFile replaces the temporal file at the provided path with one holding only the provided instants.
+func rewriteTemporalFile[T any](path string, codec Codec[T], instants ...instant[T]) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	err = file.Chmod(0644)
+	if err == nil {
+		_, err = file.Write(temporalHeader)
+	}
+	if err == nil {
+		err = writeInstants(file, codec, instants...)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// openTemporalFile opens the temporal file for appending, writing the header if the file is new.
+func openTemporalFile(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if info.Size() == 0 {
+		if _, err = file.Write(temporalHeader); err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+	}
+	return file, nil
+}
+
+func writeInstants[T any](w io.Writer, codec Codec[T], instants ...instant[T]) error {
+	if len(instants) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, inst := range instants {
+		data, err := codec.Encode(inst.Element)
+		if err != nil {
+			return err
+		}
+		if uint64(len(data)) > uint64(TemporalRecordLimit) {
+			return fmt.Errorf("an element encoded into %d bytes, beyond the TemporalRecordLimit of %d", len(data), TemporalRecordLimit)
+		}
+		_ = binary.Write(&buf, binary.BigEndian, inst.Moment.UnixNano())
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(data)))
+		buf.Write(data)
+	}
+
+	// Each batch lands in a single write, keeping the file append-only and record aligned
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// readInstants decodes each instant from the temporal file at the provided path and hands it to the provided function
+// until the file is exhausted or the context is done.
+func readInstants[T any](ctx context.Context, path string, codec Codec[T], fn func(instant[T])) error {
+	file, r, err := openInstants(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return decodeInstants(ctx, r, codec, fn)
+}
+
+// openInstants opens the temporal file at the provided path for reading, positioned just after its validated header.
+func openInstants(path string) (*os.File, *bufio.Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := bufio.NewReader(file)
+
+	header := make([]byte, len(temporalHeader))
+	if _, err = io.ReadFull(r, header); err != nil || !bytes.Equal(header, temporalHeader) {
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("%s is not a temporal buffer file", path)
+	}
+	return file, r, nil
+}
+
+// decodeInstants decodes each instant from the reader and hands it to the provided function until the reader is
+// exhausted or the context is done.
+func decodeInstants[T any](ctx context.Context, r io.Reader, codec Codec[T], fn func(instant[T])) error {
+	var err error
+	for ctx.Err() == nil {
+		var nanos int64
+		var length uint32
+		if err = binary.Read(r, binary.BigEndian, &nanos); err != nil {
+			break
+		}
+		if err = binary.Read(r, binary.BigEndian, &length); err != nil {
+			break
+		}
+		if length > TemporalRecordLimit {
+			return fmt.Errorf("a record claims %d bytes, beyond the TemporalRecordLimit of %d", length, TemporalRecordLimit)
+		}
+		data := make([]byte, length)
+		if _, err = io.ReadFull(r, data); err != nil {
+			break
+		}
+
+		element, decodeErr := codec.Decode(data)
+		if decodeErr != nil {
+			return decodeErr
+		}
+		fn(instant[T]{
+			Moment:  time.Unix(0, nanos),
+			Element: element,
+		})
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		// A partial trailing record is the signature of an interrupted write
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,177 @@
+package std
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestReplayRejectsForeignFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "foreign")
+	if err := os.WriteFile(path, []byte("not a temporal buffer"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Replay[string](context.Background(), path, 0); err == nil {
+		t.Fatal("expected a foreign file to be rejected before replaying")
+	}
+	if _, _, err := Replay[string](context.Background(), filepath.Join(t.TempDir(), "missing"), 0); err == nil {
+		t.Fatal("expected a missing file to be rejected before replaying")
+	}
+}
+
+func TestReplayReportsDecodeErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corrupt.tbuf")
+	window := time.Hour
+	b := NewTemporalBuffer[string](&window)
+	b.Record(time.Now(), "first")
+	b.Record(time.Now(), "second")
+	if err := b.Snapshot(path); err != nil {
+		t.Fatal(err)
+	}
+
+	corrupt := errors.New("corrupt element")
+	calls := 0
+	codec := GobCodec[string]()
+	decode := codec.Decode
+	codec.Decode = func(data []byte) (string, error) {
+		if calls++; calls > 1 {
+			return "", corrupt
+		}
+		return decode(data)
+	}
+
+	instants, failures, err := Replay(context.Background(), path, 0, codec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for range instants {
+		n++
+	}
+	if n != 1 {
+		t.Fatalf("replayed %d instants before the corruption, want 1", n)
+	}
+	if err = <-failures; !errors.Is(err, corrupt) {
+		t.Fatalf("failure = %v, want %v", err, corrupt)
+	}
+	if _, open := <-failures; open {
+		t.Fatal("expected the failure channel to be closed")
+	}
+}
+
+func TestReplayIgnoresPartialTrailingRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partial.tbuf")
+	window := time.Hour
+	b := NewTemporalBuffer[string](&window)
+	b.Record(time.Now(), "whole")
+	if err := b.Snapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = file.Write([]byte{1, 2, 3})
+	_ = file.Close()
+
+	instants, failures, err := Replay[string](context.Background(), path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := 0
+	for range instants {
+		n++
+	}
+	if err = <-failures; n != 1 || err != nil {
+		t.Fatalf("replayed %d instants with failure %v, want 1 and none", n, err)
+	}
+}
+
+// rehydrated reads the temporal file at the provided path into a fresh buffer and yields its elements.
+func rehydrated(t *testing.T, path string) []string {
+	t.Helper()
+	window := time.Hour
+	b := NewTemporalBuffer[string](&window)
+	if err := b.Rehydrate(path); err != nil {
+		t.Fatal(err)
+	}
+	var out []string
+	for _, inst := range b.Yield() {
+		out = append(out, inst.Element)
+	}
+	return out
+}
+
+func TestTemporalBufferPersistenceRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "round.tbuf")
+	window := time.Hour
+	b := NewTemporalBuffer[string](&window)
+	now := time.Now()
+	b.Record(now.Add(-3*time.Second), "a")
+	b.Record(now.Add(-2*time.Second), "b")
+
+	// Snapshots replace the file, so repeating one doesn't duplicate its records
+	if err := b.Snapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Snapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := rehydrated(t, path); !slices.Equal(got, []string{"a", "b"}) {
+		t.Fatalf("rehydrated %v after two snapshots, want [a b]", got)
+	}
+
+	// Persisting after a snapshot rewrites the same contents before appending new recordings
+	ctx, cancel := context.WithCancel(context.Background())
+	persisted := make(chan error, 1)
+	changes := b.Subscribe(ctx)
+	go func() { persisted <- b.Persist(ctx, path) }()
+	for deadline := time.Now().Add(time.Second); ; {
+		if got := rehydrated(t, path); len(got) == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected Persist to write its initial snapshot")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	b.Record(now.Add(-time.Second), "c")
+	<-changes
+	cancel()
+	if err := <-persisted; err != nil {
+		t.Fatal(err)
+	}
+	if got := rehydrated(t, path); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Fatalf("rehydrated %v after persisting, want [a b c]", got)
+	}
+}
+
+func TestTemporalFileRecordLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "huge.tbuf")
+	record := append([]byte{}, temporalHeader...)
+	record = binary.BigEndian.AppendUint64(record, uint64(time.Now().UnixNano()))
+	record = binary.BigEndian.AppendUint32(record, math.MaxUint32)
+	if err := os.WriteFile(path, record, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	window := time.Hour
+	b := NewTemporalBuffer[string](&window)
+	if err := b.Rehydrate(path); err == nil {
+		t.Fatal("expected a record claiming more than the TemporalRecordLimit to fail")
+	}
+
+	limit := TemporalRecordLimit
+	TemporalRecordLimit = 4
+	defer func() { TemporalRecordLimit = limit }()
+	b.Record(time.Now(), "far too long to encode")
+	if err := b.Snapshot(filepath.Join(t.TempDir(), "long.tbuf")); err == nil {
+		t.Fatal("expected an element encoding beyond the TemporalRecordLimit to fail")
+	}
+}
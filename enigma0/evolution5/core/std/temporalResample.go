@@ -0,0 +1,103 @@
+package std
+
+import (
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/interpolation"
+	"git.ignitelabs.net/janos/core/sys/num/tiny"
+)
+
+// Resample projects the buffered elements onto a uniform grid of 'count' moments, beginning at 'start' and spaced
+// 'step' apart.  Each grid point is derived from the surrounding samples through the provided interpolation.Mode.
+//
+// NOTE: Grid points before the first sample or after the last simply hold the nearest edge's value.
+//
+// NOTE: If the elements are NOT implicitly parseable, this will panic.  In that case, please provide a 'parseFn'
+// which translates the buffered information into a parseable type.  A parseable type is any numeric, string, or function provider type.
+func (b *TemporalBuffer[T]) Resample(start time.Time, step time.Duration, count uint, mode interpolation.Mode, parseFn ...func(T) any) []instant[any] {
+	if count == 0 {
+		return []instant[any]{}
+	}
+	first, last := start, start.Add(step*time.Duration(count-1))
+	if last.Before(first) {
+		first, last = last, first
+	}
+
+	// Only the samples spanning the grid are needed - plus two on either side for cubic tangents
+	var samples []instant[T]
+	b.access(func() {
+		low := b.buffer.notBefore(first) - 2
+		high := b.buffer.after(last) + 2
+		samples = b.buffer.slice(low, high)
+	})
+	if len(samples) == 0 {
+		return []instant[any]{}
+	}
+
+	values := make([]float64, len(samples))
+	for i, inst := range samples {
+		var number any
+		if len(parseFn) > 0 {
+			number = parseFn[0](inst.Element)
+		} else {
+			number = inst.Element
+		}
+		values[i] = tiny.ParseInto[float64](number)
+	}
+
+	var tangents []instant[any]
+	if mode == interpolation.Cubic {
+		tangents = differentiate(samples, 1, parseFn...)
+	}
+
+	out := make([]instant[any], count)
+	next := 0
+	for i := range out {
+		moment := start.Add(step * time.Duration(i))
+
+		// Find the samples surrounding this moment, such that samples[next-1] <= moment < samples[next]
+		for next > 0 && samples[next-1].Moment.After(moment) {
+			next--
+		}
+		for next < len(samples) && !samples[next].Moment.After(moment) {
+			next++
+		}
+
+		var value float64
+		switch {
+		case next == 0:
+			value = values[0]
+		case next == len(samples):
+			value = values[len(values)-1]
+		default:
+			before, after := next-1, next
+			span := samples[after].Moment.Sub(samples[before].Moment).Seconds()
+			offset := moment.Sub(samples[before].Moment).Seconds()
+			s := offset / span
+
+			switch mode {
+			case interpolation.Hold:
+				value = values[before]
+			case interpolation.Linear:
+				value = values[before] + (values[after]-values[before])*s
+			case interpolation.Cubic:
+				// A cubic Hermite spline, using the non-uniform derivatives at each sample as its tangents
+				m0 := tangents[before].Element.(float64) * span
+				m1 := tangents[after].Element.(float64) * span
+				s2, s3 := s*s, s*s*s
+				value = (2*s3-3*s2+1)*values[before] + (s3-2*s2+s)*m0 + (-2*s3+3*s2)*values[after] + (s3-s2)*m1
+			default:
+				value = values[before]
+				if s > 0.5 {
+					value = values[after]
+				}
+			}
+		}
+
+		out[i] = instant[any]{
+			Moment:  moment,
+			Element: value,
+		}
+	}
+	return out
+}
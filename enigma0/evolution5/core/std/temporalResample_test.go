@@ -0,0 +1,73 @@
+package std
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/interpolation"
+)
+
+func TestTemporalBufferResample(t *testing.T) {
+	window := time.Hour
+	b := NewTemporalBuffer[float64](&window)
+	base := time.Now().Add(-time.Minute)
+	for i := 0; i < 4; i++ {
+		b.Record(base.Add(time.Duration(i)*time.Second), float64(3*i+1))
+	}
+
+	tests := []struct {
+		name  string
+		start time.Duration
+		step  time.Duration
+		count uint
+		mode  interpolation.Mode
+		want  []float64
+	}{
+		{"nearest", 0, 700 * time.Millisecond, 5, interpolation.Nearest, []float64{1, 4, 4, 7, 10}},
+		{"hold", 0, 700 * time.Millisecond, 5, interpolation.Hold, []float64{1, 1, 4, 7, 7}},
+		{"linear", 0, 700 * time.Millisecond, 5, interpolation.Linear, []float64{1, 3.1, 5.2, 7.3, 9.4}},
+		{"cubic", 0, 700 * time.Millisecond, 5, interpolation.Cubic, []float64{1, 3.1, 5.2, 7.3, 9.4}},
+		{"edges hold", -time.Second, 2500 * time.Millisecond, 3, interpolation.Linear, []float64{1, 5.5, 10}},
+		{"reversed", 3 * time.Second, -time.Second, 4, interpolation.Linear, []float64{10, 7, 4, 1}},
+		{"empty grid", 0, time.Second, 0, interpolation.Linear, []float64{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			start := base.Add(test.start)
+			got := b.Resample(start, test.step, test.count, test.mode)
+			if len(got) != len(test.want) {
+				t.Fatalf("resampled %d points, want %d", len(got), len(test.want))
+			}
+			for i, inst := range got {
+				if moment := start.Add(test.step * time.Duration(i)); !inst.Moment.Equal(moment) {
+					t.Errorf("point %d at %v, want %v", i, inst.Moment, moment)
+				}
+				if value := inst.Element.(float64); math.Abs(value-test.want[i]) > 1e-9 {
+					t.Errorf("point %d = %v, want %v", i, value, test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTemporalBufferResampleParse(t *testing.T) {
+	type reading struct{ celsius float64 }
+	window := time.Hour
+	b := NewTemporalBuffer[reading](&window)
+	base := time.Now().Add(-time.Minute)
+	b.Record(base, reading{10})
+	b.Record(base.Add(time.Second), reading{20})
+
+	got := b.Resample(base, 250*time.Millisecond, 5, interpolation.Linear, func(r reading) any { return r.celsius })
+	for i, inst := range got {
+		if want := 10 + 2.5*float64(i); inst.Element.(float64) != want {
+			t.Errorf("point %d = %v, want %v", i, inst.Element, want)
+		}
+	}
+
+	empty := NewTemporalBuffer[float64](&window)
+	if got := empty.Resample(base, time.Second, 3, interpolation.Linear); len(got) != 0 {
+		t.Errorf("resampled %v from an empty buffer, want nothing", got)
+	}
+}
@@ -0,0 +1,122 @@
+package std
+
+import (
+	"time"
+	"unsafe"
+
+	"git.ignitelabs.net/janos/core/sys/atlas"
+)
+
+// expired returns how many of the oldest instants fall outside the buffer's retention policies.  Each policy
+// can only grow the number of expired instants, before the Minimum (or atlas.ObservedMinimum) floor reins it back in.
+//
+// NOTE: This must be called while holding the buffer's master lock.
+func (b *TemporalBuffer[T]) expired() int {
+	cutoff := time.Now().Add(-*b.Window)
+
+	n := b.buffer.len()
+	i := b.buffer.after(cutoff)
+
+	if b.Limit > 0 && n-i > int(b.Limit) {
+		i = n - int(b.Limit)
+	}
+
+	if b.Budget > 0 {
+		// Only the instants already expiring are measured, rather than everything the buffer holds
+		total := b.bytes
+		for j := 0; j < i; j++ {
+			total -= min(total, b.sizeOf(b.buffer.at(j).Element))
+		}
+		for ; i < n && total > b.Budget; i++ {
+			total -= min(total, b.sizeOf(b.buffer.at(i).Element))
+		}
+	}
+
+	minimum := atlas.ObservedMinimum
+	if b.Minimum != nil {
+		minimum = *b.Minimum
+	}
+	maximum := n - int(minimum)
+	if maximum < 0 {
+		maximum = 0
+	}
+	if i > maximum {
+		i = maximum
+	}
+	return i
+}
+
+func (b *TemporalBuffer[T]) sizeOf(element T) uint {
+	if b.Size != nil {
+		return b.Size(element)
+	}
+	return uint(unsafe.Sizeof(element))
+}
+
+// downsample merges the provided trimmed instants into the History.  Instants are grouped into spans of Resolution,
+// and each span is merged through Downsample into a single aggregate stamped with the span's newest moment.
+//
+// NOTE: If the newest aggregate shares a span with the oldest trimmed instants, it's handed back to Downsample
+// alongside them - so your function should be comfortable merging its own prior aggregates.
+//
+// NOTE: This must be called while holding the buffer's master lock.
+func (b *TemporalBuffer[T]) downsample(trimmed []instant[T]) {
+	if len(trimmed) == 0 {
+		return
+	}
+
+	resolution := *b.Window
+	if b.Resolution != nil {
+		resolution = *b.Resolution
+	}
+
+	var span []instant[T]
+	var spanStart time.Time
+	if last := len(b.history) - 1; last >= 0 && b.history[last].Moment.Truncate(resolution).Equal(trimmed[0].Moment.Truncate(resolution)) {
+		span = append(span, b.history[last])
+		spanStart = b.history[last].Moment.Truncate(resolution)
+		b.history = b.history[:last]
+	}
+
+	merge := func() {
+		if len(span) == 0 {
+			return
+		}
+		element := span[0].Element
+		if len(span) > 1 {
+			element = b.Downsample(span)
+		}
+		b.history = append(b.history, instant[T]{
+			Moment:  span[len(span)-1].Moment,
+			Element: element,
+		})
+	}
+
+	for _, inst := range trimmed {
+		start := inst.Moment.Truncate(resolution)
+		if len(span) > 0 && !start.Equal(spanStart) {
+			merge()
+			span = nil
+		}
+		spanStart = start
+		span = append(span, inst)
+	}
+	merge()
+
+	if b.Limit > 0 && len(b.history) > int(b.Limit) {
+		b.history = b.history[len(b.history)-int(b.Limit):]
+	}
+}
+
+// History returns a thread-independent copy of the downsampled aggregates in temporal order.
+//
+// NOTE: This is always empty unless a Downsample function has been provided.
+func (b *TemporalBuffer[T]) History() []instant[T] {
+	b.sanityCheck()
+	b.master.Lock()
+	defer b.master.Unlock()
+
+	out := make([]instant[T], len(b.history))
+	copy(out, b.history)
+	return out
+}
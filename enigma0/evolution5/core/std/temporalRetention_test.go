@@ -0,0 +1,130 @@
+package std
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTemporalBufferRetention(t *testing.T) {
+	none := uint(0)
+	two := uint(2)
+	now := time.Now()
+	tests := []struct {
+		name      string
+		configure func(b *TemporalBuffer[int])
+		offsets   []time.Duration
+		want      []int
+	}{
+		{
+			name:      "window",
+			configure: func(b *TemporalBuffer[int]) { b.Minimum = &none },
+			offsets:   []time.Duration{-2 * time.Hour, -90 * time.Minute, -time.Minute, 0},
+			want:      []int{2, 3},
+		},
+		{
+			name:      "limit",
+			configure: func(b *TemporalBuffer[int]) { b.Minimum = &none; b.Limit = 2 },
+			offsets:   []time.Duration{-4 * time.Second, -3 * time.Second, -2 * time.Second, -time.Second},
+			want:      []int{2, 3},
+		},
+		{
+			name: "budget measured by size",
+			configure: func(b *TemporalBuffer[int]) {
+				b.Minimum = &none
+				b.Budget = 10
+				b.Size = func(element int) uint { return uint(element*3 + 1) }
+			},
+			offsets: []time.Duration{-4 * time.Second, -3 * time.Second, -2 * time.Second, -time.Second},
+			want:    []int{3},
+		},
+		{
+			name:      "minimum outweighs the window",
+			configure: func(b *TemporalBuffer[int]) { b.Minimum = &two },
+			offsets:   []time.Duration{-4 * time.Hour, -3 * time.Hour, -2 * time.Hour},
+			want:      []int{1, 2},
+		},
+		{
+			name:      "minimum outweighs the limit",
+			configure: func(b *TemporalBuffer[int]) { b.Minimum = &two; b.Limit = 1 },
+			offsets:   []time.Duration{-3 * time.Second, -2 * time.Second, -time.Second},
+			want:      []int{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := time.Hour
+			b := NewTemporalBuffer[int](&window)
+			tt.configure(b)
+			for i, offset := range tt.offsets {
+				b.Record(now.Add(offset), i)
+			}
+
+			yield := b.Yield()
+			if len(yield) != len(tt.want) {
+				t.Fatalf("held %d instants, want %d", len(yield), len(tt.want))
+			}
+			for i, inst := range yield {
+				if inst.Element != tt.want[i] {
+					t.Fatalf("instant %d = %d, want %d", i, inst.Element, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTemporalBufferBudgetRunningTotal(t *testing.T) {
+	window := time.Hour
+	none := uint(0)
+	b := NewTemporalBuffer[int](&window)
+	b.Minimum = &none
+	b.Budget = 20
+	b.Size = func(element int) uint { return uint(element%7 + 1) }
+
+	now := time.Now()
+	for i := 0; i < 200; i++ {
+		b.Record(now.Add(time.Duration(i)*time.Millisecond), i)
+
+		var total uint
+		for _, inst := range b.Yield() {
+			total += b.Size(inst.Element)
+		}
+		if total != b.bytes || total > b.Budget {
+			t.Fatalf("after %d records the running total is %d, but the held elements measure %d against a budget of %d", i+1, b.bytes, total, b.Budget)
+		}
+	}
+}
+
+func TestTemporalBufferDownsample(t *testing.T) {
+	window := time.Hour
+	resolution := 10 * time.Second
+	none := uint(0)
+	b := NewTemporalBuffer[int](&window)
+	b.Minimum = &none
+	b.Limit = 3
+	b.Resolution = &resolution
+	b.Downsample = func(span []instant[int]) int {
+		var sum int
+		for _, inst := range span {
+			sum += inst.Element
+		}
+		return sum
+	}
+
+	base := time.Now().Truncate(time.Minute).Add(-30 * time.Minute)
+	for i := 0; i < 10; i++ {
+		b.Record(base.Add(time.Duration(i)*3*time.Second), i)
+	}
+	if b.Len() != 3 {
+		t.Fatalf("held %d instants, want the limit of 3", b.Len())
+	}
+
+	// Every 3s recording from 0s to 18s is trimmed into the 10s spans [0 1 2 3] and [4 5 6]
+	history := b.History()
+	if len(history) != 2 || history[0].Element != 6 || history[1].Element != 15 {
+		t.Fatalf("history = %v, want the aggregates 6 and 15", history)
+	}
+	if !history[0].Moment.Equal(base.Add(9*time.Second)) || !history[1].Moment.Equal(base.Add(18*time.Second)) {
+		t.Fatalf("history = %v, want each aggregate stamped with its span's newest moment", history)
+	}
+}
@@ -0,0 +1,144 @@
+package std
+
+import (
+	"sort"
+	"time"
+)
+
+// A temporalRing is the circular storage behind a TemporalBuffer.  Instants are held in temporal order starting
+// from 'head' and wrapping around the end of 'data' - allowing the oldest instants to be trimmed in constant time,
+// and any moment to be found with a binary search.
+//
+// NOTE: A temporalRing is not thread-safe on its own - it relies upon the TemporalBuffer's master lock.
+type temporalRing[T any] struct {
+	data  []instant[T]
+	head  int
+	count int
+}
+
+func newTemporalRing[T any]() *temporalRing[T] {
+	return &temporalRing[T]{
+		data: make([]instant[T], 0),
+	}
+}
+
+func (r *temporalRing[T]) len() int {
+	return r.count
+}
+
+// index translates a logical position into its position within 'data'.
+func (r *temporalRing[T]) index(i int) int {
+	i += r.head
+	if i >= len(r.data) {
+		i -= len(r.data)
+	}
+	return i
+}
+
+// at returns the instant at the provided logical position.
+func (r *temporalRing[T]) at(i int) instant[T] {
+	return r.data[r.index(i)]
+}
+
+// after returns the logical position of the first instant which is after the provided moment.
+func (r *temporalRing[T]) after(moment time.Time) int {
+	return sort.Search(r.count, func(i int) bool {
+		return r.at(i).Moment.After(moment)
+	})
+}
+
+// notBefore returns the logical position of the first instant which is not before the provided moment.
+func (r *temporalRing[T]) notBefore(moment time.Time) int {
+	return sort.Search(r.count, func(i int) bool {
+		return !r.at(i).Moment.Before(moment)
+	})
+}
+
+// grow doubles the ring's capacity, unwrapping its contents to begin at position 0.
+func (r *temporalRing[T]) grow() {
+	capacity := len(r.data) * 2
+	if capacity == 0 {
+		capacity = 8
+	}
+	data := make([]instant[T], capacity)
+	r.copyInto(data, 0, r.count)
+	r.data = data
+	r.head = 0
+}
+
+// insert places the provided instant at its temporal position and returns where it landed.  Instants which share
+// a moment are kept in the order they were inserted.
+//
+// NOTE: Only the elements on the shorter side of the insertion point are shifted, meaning the typical
+// case of recording "now" is a constant time operation.
+func (r *temporalRing[T]) insert(inst instant[T]) int {
+	if r.count == len(r.data) {
+		r.grow()
+	}
+
+	pos := r.after(inst.Moment)
+	if pos < r.count-pos {
+		// Shift the older side one position backwards
+		r.head--
+		if r.head < 0 {
+			r.head += len(r.data)
+		}
+		for i := 0; i < pos; i++ {
+			r.data[r.index(i)] = r.data[r.index(i+1)]
+		}
+	} else {
+		// Shift the newer side one position forwards
+		for i := r.count; i > pos; i-- {
+			r.data[r.index(i)] = r.data[r.index(i-1)]
+		}
+	}
+	r.data[r.index(pos)] = inst
+	r.count++
+	return pos
+}
+
+// shift removes the oldest 'n' instants and returns them as a new slice.
+func (r *temporalRing[T]) shift(n int) []instant[T] {
+	if n > r.count {
+		n = r.count
+	}
+	out := r.slice(0, n)
+
+	var zero instant[T]
+	for i := 0; i < n; i++ {
+		// Release any references held by the removed elements
+		r.data[r.index(i)] = zero
+	}
+	r.head = r.index(n)
+	r.count -= n
+	if r.count == 0 {
+		r.head = 0
+	}
+	return out
+}
+
+// slice returns a new slice holding the logical range [low:high].
+func (r *temporalRing[T]) slice(low, high int) []instant[T] {
+	if low < 0 {
+		low = 0
+	}
+	if high > r.count {
+		high = r.count
+	}
+	if high <= low {
+		return []instant[T]{}
+	}
+	out := make([]instant[T], high-low)
+	r.copyInto(out, low, high)
+	return out
+}
+
+// copyInto copies the logical range [low:high] into the destination using at most two contiguous copies.
+func (r *temporalRing[T]) copyInto(destination []instant[T], low, high int) {
+	if high <= low {
+		return
+	}
+	start := r.index(low)
+	n := copy(destination, r.data[start:min(start+high-low, len(r.data))])
+	copy(destination[n:], r.data[:high-low-n])
+}
@@ -0,0 +1,163 @@
+package std
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// A sliceStorage reproduces the flat slice storage which backed a TemporalBuffer before the temporalRing, so the
+// benchmarks below can compare the two.
+type sliceStorage[T any] struct {
+	buffer []instant[T]
+}
+
+// insert scans backwards from the newest instant to find the insertion point, then shifts everything after it.
+func (s *sliceStorage[T]) insert(inst instant[T]) {
+	t := len(s.buffer)
+	for t > 0 && !s.buffer[t-1].Moment.Before(inst.Moment) {
+		t--
+	}
+	s.buffer = slices.Insert(s.buffer, t, inst)
+}
+
+// shift trims the oldest 'n' instants by re-slicing.
+func (s *sliceStorage[T]) shift(n int) {
+	s.buffer = s.buffer[n:]
+}
+
+// latestSince copies the entire buffer before scanning backwards for the moment.
+func (s *sliceStorage[T]) latestSince(moment time.Time) []instant[T] {
+	out := make([]instant[T], len(s.buffer))
+	copy(out, s.buffer)
+	point := len(out)
+	for point > 0 && out[point-1].Moment.After(moment) {
+		point--
+	}
+	return out[point:]
+}
+
+// between copies the entire buffer before filtering it down to the range.
+func (s *sliceStorage[T]) between(from, to time.Time) []instant[T] {
+	out := make([]instant[T], len(s.buffer))
+	copy(out, s.buffer)
+	low := 0
+	for low < len(out) && out[low].Moment.Before(from) {
+		low++
+	}
+	high := low
+	for high < len(out) && !out[high].Moment.After(to) {
+		high++
+	}
+	return out[low:high]
+}
+
+var benchmarkSizes = []int{1 << 10, 1 << 16}
+
+// benchmarkMoments returns 'n' moments spaced a millisecond apart.
+func benchmarkMoments(n int) []time.Time {
+	start := time.Now()
+	moments := make([]time.Time, n)
+	for i := range moments {
+		moments[i] = start.Add(time.Duration(i) * time.Millisecond)
+	}
+	return moments
+}
+
+func filledStorage(moments []time.Time) (*sliceStorage[int], *temporalRing[int]) {
+	s := &sliceStorage[int]{}
+	r := newTemporalRing[int]()
+	for i, moment := range moments {
+		s.insert(instant[int]{moment, i})
+		r.insert(instant[int]{moment, i})
+	}
+	return s, r
+}
+
+// BenchmarkTemporalStorageRecord records "now" into a full buffer while trimming its oldest instant, as a
+// TemporalBuffer does once its window is saturated.
+func BenchmarkTemporalStorageRecord(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		moments := benchmarkMoments(n)
+		last := moments[n-1]
+
+		b.Run(fmt.Sprintf("slice/%d", n), func(b *testing.B) {
+			s, _ := filledStorage(moments)
+			for i := 0; b.Loop(); i++ {
+				s.insert(instant[int]{last.Add(time.Duration(i+1) * time.Millisecond), i})
+				s.shift(1)
+			}
+		})
+		b.Run(fmt.Sprintf("ring/%d", n), func(b *testing.B) {
+			_, r := filledStorage(moments)
+			for i := 0; b.Loop(); i++ {
+				r.insert(instant[int]{last.Add(time.Duration(i+1) * time.Millisecond), i})
+				r.shift(1)
+			}
+		})
+	}
+}
+
+// BenchmarkTemporalStorageRecordMidpoint records into the middle of a full buffer - the worst case for both.
+func BenchmarkTemporalStorageRecordMidpoint(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		moments := benchmarkMoments(n)
+		middle := moments[n/2]
+
+		b.Run(fmt.Sprintf("slice/%d", n), func(b *testing.B) {
+			s, _ := filledStorage(moments)
+			for i := 0; b.Loop(); i++ {
+				s.insert(instant[int]{middle, i})
+				s.shift(1)
+			}
+		})
+		b.Run(fmt.Sprintf("ring/%d", n), func(b *testing.B) {
+			_, r := filledStorage(moments)
+			for i := 0; b.Loop(); i++ {
+				r.insert(instant[int]{middle, i})
+				r.shift(1)
+			}
+		})
+	}
+}
+
+// BenchmarkTemporalStorageLatestSince requests the most recent tenth of the buffer.
+func BenchmarkTemporalStorageLatestSince(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		moments := benchmarkMoments(n)
+		since := moments[n-n/10]
+		s, r := filledStorage(moments)
+
+		b.Run(fmt.Sprintf("slice/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				_ = s.latestSince(since)
+			}
+		})
+		b.Run(fmt.Sprintf("ring/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				_ = r.slice(r.after(since), r.len())
+			}
+		})
+	}
+}
+
+// BenchmarkTemporalStorageBetween requests a tenth of the buffer from its middle.
+func BenchmarkTemporalStorageBetween(b *testing.B) {
+	for _, n := range benchmarkSizes {
+		moments := benchmarkMoments(n)
+		from, to := moments[n/2], moments[n/2+n/10]
+		s, r := filledStorage(moments)
+
+		b.Run(fmt.Sprintf("slice/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				_ = s.between(from, to)
+			}
+		})
+		b.Run(fmt.Sprintf("ring/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				_ = r.slice(r.notBefore(from), r.after(to))
+			}
+		})
+	}
+}
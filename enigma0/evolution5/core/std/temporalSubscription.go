@@ -0,0 +1,143 @@
+package std
+
+import (
+	"context"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/backpressure"
+)
+
+// SubscriptionDepth defines how many undelivered instants a backpressure.DropOldest subscriber can hold before the
+// oldest is discarded.
+var SubscriptionDepth = 64
+
+// A temporalSubscription is a single channel a TemporalBuffer fans its instants out to.
+type temporalSubscription[T any] struct {
+	channel chan instant[T]
+	policy  backpressure.Policy
+	ctx     context.Context
+	gate    Gate
+	closed  bool
+}
+
+// Subscribe returns a channel which receives every instant as it's recorded into the buffer.  The channel is closed
+// once the provided context is done.  If no backpressure.Policy is provided, backpressure.DropOldest is implied.
+//
+// NOTE: Instants are delivered in the order they were -recorded,- not in temporal order.
+func (b *TemporalBuffer[T]) Subscribe(ctx context.Context, policy ...backpressure.Policy) <-chan instant[T] {
+	b.sanityCheck()
+	b.master.Lock()
+	defer b.master.Unlock()
+
+	sub := newTemporalSubscription[T](ctx, policy...)
+	b.subscribers = append(b.subscribers, sub)
+	go b.unsubscribe(sub, &b.subscribers)
+	return sub.channel
+}
+
+// Evictions returns a channel which receives every instant as it's trimmed out of the buffer.  The channel is closed
+// once the provided context is done.  If no backpressure.Policy is provided, backpressure.DropOldest is implied.
+func (b *TemporalBuffer[T]) Evictions(ctx context.Context, policy ...backpressure.Policy) <-chan instant[T] {
+	b.sanityCheck()
+	b.master.Lock()
+	defer b.master.Unlock()
+
+	sub := newTemporalSubscription[T](ctx, policy...)
+	b.evictors = append(b.evictors, sub)
+	go b.unsubscribe(sub, &b.evictors)
+	return sub.channel
+}
+
+func newTemporalSubscription[T any](ctx context.Context, policy ...backpressure.Policy) *temporalSubscription[T] {
+	p := backpressure.DropOldest
+	if len(policy) > 0 {
+		p = policy[0]
+	}
+
+	var channel chan instant[T]
+	switch p {
+	case backpressure.Block:
+		channel = make(chan instant[T])
+	case backpressure.Coalesce:
+		channel = make(chan instant[T], 1)
+	default:
+		channel = make(chan instant[T], SubscriptionDepth)
+	}
+
+	return &temporalSubscription[T]{
+		channel: channel,
+		policy:  p,
+		ctx:     ctx,
+	}
+}
+
+// unsubscribe waits for the subscription's context to finish before removing it from the provided list and closing its channel.
+func (b *TemporalBuffer[T]) unsubscribe(sub *temporalSubscription[T], from *[]*temporalSubscription[T]) {
+	<-sub.ctx.Done()
+
+	b.master.Lock()
+	for i, s := range *from {
+		if s == sub {
+			*from = append((*from)[:i:i], (*from)[i+1:]...)
+			break
+		}
+	}
+	b.master.Unlock()
+
+	sub.gate.Lock()
+	defer sub.gate.Unlock()
+	sub.closed = true
+	close(sub.channel)
+}
+
+// snapshot returns a thread-independent copy of the provided subscriber list.
+//
+// NOTE: This must be called while holding the buffer's master lock.
+func snapshot[T any](subs []*temporalSubscription[T]) []*temporalSubscription[T] {
+	if len(subs) == 0 {
+		return nil
+	}
+	out := make([]*temporalSubscription[T], len(subs))
+	copy(out, subs)
+	return out
+}
+
+// publish fans the provided instants out to every subscriber according to their backpressure.Policy.
+//
+// NOTE: This must be called -without- holding the buffer's master lock, as blocking subscribers may take their time.
+func publish[T any](subs []*temporalSubscription[T], instants ...instant[T]) {
+	for _, sub := range subs {
+		for _, inst := range instants {
+			sub.deliver(inst)
+		}
+	}
+}
+
+func (sub *temporalSubscription[T]) deliver(inst instant[T]) {
+	sub.gate.Lock()
+	defer sub.gate.Unlock()
+
+	if sub.closed {
+		return
+	}
+
+	switch sub.policy {
+	case backpressure.Block:
+		select {
+		case sub.channel <- inst:
+		case <-sub.ctx.Done():
+		}
+	default:
+		for {
+			select {
+			case sub.channel <- inst:
+				return
+			default:
+				// Make room by discarding the oldest undelivered instant
+				select {
+				case <-sub.channel:
+				default:
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package std
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/backpressure"
+)
+
+// received drains every instant currently waiting on the channel.
+func received[T any](channel <-chan instant[T]) []T {
+	var out []T
+	for {
+		select {
+		case inst := <-channel:
+			out = append(out, inst.Element)
+		default:
+			return out
+		}
+	}
+}
+
+func TestTemporalBufferSubscribe(t *testing.T) {
+	depth := SubscriptionDepth
+	SubscriptionDepth = 3
+	defer func() { SubscriptionDepth = depth }()
+
+	window := time.Hour
+	b := NewTemporalBuffer[int](&window)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dropOldest := b.Subscribe(ctx)
+	coalesce := b.Subscribe(ctx, backpressure.Coalesce)
+
+	now := time.Now()
+	for i, offset := range []time.Duration{-time.Second, -5 * time.Second, -3 * time.Second, -4 * time.Second, -2 * time.Second} {
+		b.Record(now.Add(offset), i)
+	}
+
+	// Delivery follows the order of recording, not the temporal order
+	if got := received(dropOldest); len(got) != 3 || got[0] != 2 || got[1] != 3 || got[2] != 4 {
+		t.Fatalf("DropOldest received %v, want the newest three recordings [2 3 4]", got)
+	}
+	if got := received(coalesce); len(got) != 1 || got[0] != 4 {
+		t.Fatalf("Coalesce received %v, want only the newest recording [4]", got)
+	}
+}
+
+func TestTemporalBufferSubscribeBlock(t *testing.T) {
+	window := time.Hour
+	b := NewTemporalBuffer[int](&window)
+	ctx, cancel := context.WithCancel(context.Background())
+	blocking := b.Subscribe(ctx, backpressure.Block)
+
+	recorded := make(chan struct{})
+	go func() {
+		b.Record(time.Now(), 1)
+		b.Record(time.Now(), 2)
+		close(recorded)
+	}()
+
+	if inst := <-blocking; inst.Element != 1 {
+		t.Fatalf("received %v, want 1", inst.Element)
+	}
+	select {
+	case <-recorded:
+		t.Fatal("expected the second Record to block until its instant was received")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// Cancelling releases the blocked publisher and closes the channel
+	cancel()
+	select {
+	case <-recorded:
+	case <-time.After(time.Second):
+		t.Fatal("expected cancelling the subscription to release the blocked Record")
+	}
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-blocking:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("expected the channel to close once its context was done")
+		}
+	}
+}
+
+func TestTemporalBufferEvictions(t *testing.T) {
+	window := time.Hour
+	minimum := uint(0)
+	b := NewTemporalBuffer[int](&window)
+	b.Limit = 2
+	b.Minimum = &minimum
+
+	ctx, cancel := context.WithCancel(context.Background())
+	evictions := b.Evictions(ctx)
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		b.Record(now.Add(time.Duration(i)*time.Millisecond), i)
+	}
+	if got := received(evictions); len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Fatalf("evicted %v, want the oldest three [0 1 2]", got)
+	}
+
+	cancel()
+	select {
+	case _, ok := <-evictions:
+		if ok {
+			t.Fatal("expected no further evictions")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the channel to close once its context was done")
+	}
+	b.Record(now.Add(time.Second), 5)
+}
@@ -1,32 +1,48 @@
 package std
 
+import (
+	"slices"
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/access"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
 // A Thought is a thread-safe and relationally.Constrained revelation.  When used as a LIQ, the inner
 // revelation is the Stringable component unless set by Thought.Stringable.
 type Thought[T any] struct {
+	id         uint64
 	revelation T
 	gate       *Gate
 	disclosure *Disclosure
 	stringable func() string
+	watchers   []*thoughtWatcher[T]
+	history    *TemporalBuffer[T]
+	revision   uint64
 	created    bool
 }
 
+// NewThought creates a new Thought holding the provided revelation and returns it alongside its Disclosure.  If no
+// disclosure is provided, a relationally.Open one with no code is created.
+//
+// NOTE: Whoever holds the disclosure controls access to the thought, so only share it with those you trust.
 func NewThought[T any](revelation T, disclosure ...*Disclosure) (*Thought[T], *Disclosure) {
-	panic("not implemented")
-
-	//d := &Disclosure{
-	//	Constraint: relationally.Open,
-	//	code:       nil,
-	//}
-	//if len(disclosure) > 0 && disclosure[0] != nil {
-	//	d = disclosure[0]
-	//}
-	//
-	//return &Thought[T]{
-	//	revelation: revelation,
-	//	gate:       new(sync.Mutex),
-	//	disclosure: d,
-	//	created:    true,
-	//}, d
+	d := &Disclosure{
+		Constraint: relationally.Open,
+		code:       nil,
+	}
+	if len(disclosure) > 0 && disclosure[0] != nil {
+		d = disclosure[0]
+	}
+
+	return &Thought[T]{
+		id:         thoughtIDs.Add(1),
+		revelation: revelation,
+		gate:       new(Gate),
+		disclosure: d,
+		created:    true,
+	}, d
 }
 
 func (t *Thought[T]) sanityCheck() {
@@ -35,30 +51,140 @@ func (t *Thought[T]) sanityCheck() {
 	}
 }
 
+// String outputs the Thought's revelation as a string, or the result of its StringifyFn if one was provided.
+//
+// NOTE: This bypasses the thought's Disclosure, so don't stringify a thought you wouldn't otherwise reveal.
 func (t Thought[T]) String() string {
-	if t.stringable != nil {
-		return t.stringable()
+	if t.gate == nil {
+		return Stringify(t.revelation)
+	}
+
+	t.gate.Lock()
+	stringable, revelation := t.stringable, t.revelation
+	t.gate.Unlock()
+
+	if stringable != nil {
+		return stringable()
 	}
-	return Stringify(t.revelation)
+	return Stringify(revelation)
 }
 
 // StringifyFn sets the method for stringing this Thought out.  If nil (default), Stringable operates directly on the
 // revelation - otherwise, the provided string function is called.
 func (t *Thought[T]) StringifyFn(fn func() string) {
+	t.sanityCheck()
+	t.gate.Lock()
+	defer t.gate.Unlock()
 	t.stringable = fn
-	panic("not implemented")
 }
 
 // Reveal returns the underlying revelation of this Thought.
 //
-// NOTE: To reveal a relative path, please use Recall.
+// NOTE: If the thought is relationally.Exclusive, the code must pass the Disclosure's Check - otherwise, this
+// returns errs.InvalidCode.  To reveal a relative path, please use Recall.
 func (t *Thought[T]) Reveal(code ...any) (T, error) {
-	panic("not implemented")
+	return t.RevealAs(nil, code...)
+}
+
+// RevealAs performs a Reveal on behalf of the provided impulse, identifying it by its Bridge to the Disclosure's Audit.
+func (t *Thought[T]) RevealAs(caller *Impulse, code ...any) (T, error) {
+	var revelation T
+	err := t.revealWith(caller, func() {
+		revelation = t.revelation
+	}, code...)
+	return revelation, err
+}
+
+// current returns the underlying revelation of this Thought without checking any code or auditing the access.
+//
+// NOTE: This is only for those who have already checked the caller's code themselves.
+func (t *Thought[T]) current() T {
+	t.gate.Lock()
+	defer t.gate.Unlock()
+	return t.revelation
 }
 
 // Describe sets the underlying revelation of this Thought.
+//
+// NOTE: If the thought is relationally.Inclusive or relationally.Exclusive, the code must pass the Disclosure's
+// Check - otherwise, this returns errs.InvalidCode.  Every successful description is delivered to the thought's watchers.
 func (t *Thought[T]) Describe(revelation T, code ...any) error {
-	panic("not implemented")
+	return t.DescribeAs(nil, revelation, code...)
+}
+
+// DescribeAs performs a Describe on behalf of the provided impulse, identifying it by its Bridge to the Disclosure's Audit.
+func (t *Thought[T]) DescribeAs(caller *Impulse, revelation T, code ...any) error {
+	t.sanityCheck()
+
+	t.gate.Lock()
+	granted := t.disclosure.Constraint == relationally.Open || t.disclosure.Check(code...)
+	var notify func()
+	if granted {
+		notify = t.revise(revelation)
+	}
+	t.gate.Unlock()
+
+	t.disclosure.audit(access.Describe, granted, caller, code...)
+	if !granted {
+		return errs.InvalidCode
+	}
+	notify()
+	return nil
+}
+
+// update reveals, modifies, and describes this Thought as a single step, holding its gate throughout so concurrent
+// updates can never lose one another's changes.  The function is handed the current revelation to modify - if it
+// returns an error, nothing is described and the error is returned.
+//
+// NOTE: The code must pass the describe constraint (which implies the reveal constraint), or this returns
+// errs.InvalidCode without calling the function.  As the gate is held, the function must not Reveal or Describe
+// this thought itself.
+func (t *Thought[T]) update(fn func(*T) error, code ...any) error {
+	t.sanityCheck()
+
+	t.gate.Lock()
+	granted := t.disclosure.Constraint == relationally.Open || t.disclosure.Check(code...)
+	var notify func()
+	var err error
+	if granted {
+		revelation := t.revelation
+		if err = fn(&revelation); err == nil {
+			notify = t.revise(revelation)
+		}
+	}
+	t.gate.Unlock()
+
+	if !granted {
+		t.disclosure.audit(access.Describe, false, nil, code...)
+		return errs.InvalidCode
+	}
+	t.disclosure.audit(access.Reveal, true, nil, code...)
+	if err != nil {
+		return err
+	}
+	t.disclosure.audit(access.Describe, true, nil, code...)
+	notify()
+	return nil
+}
+
+// revise replaces the revelation, remembering it if the thought keeps a history, and returns a function which
+// notifies the thought's watchers of the change.
+//
+// NOTE: This must be called while holding the thought's gate - but the returned function must be called without it.
+func (t *Thought[T]) revise(revelation T) func() {
+	moment := time.Now()
+	change := Change[T]{Old: t.revelation, New: revelation}
+	watchers := slices.Clone(t.watchers)
+
+	t.revelation = revelation
+	t.revision++
+	if t.history != nil {
+		t.history.Record(moment, revelation)
+	}
+
+	return func() {
+		t.notify(watchers, moment, change)
+	}
 }
 
 // Recall walks the provided Path relative to the current Thought and yields the result.
@@ -66,8 +192,6 @@ func (t *Thought[T]) Describe(revelation T, code ...any) error {
 // NOTE: The code is used at any constrained points in the path, otherwise it's ignored. If you
 // need to use multiple codes, you must sequentially reveal each codified part of the path.
 func (t *Thought[T]) Recall(relative Path) (any, error) {
-
-	panic("not implemented")
-
-	//return Locate(t, relative)
+	t.sanityCheck()
+	return Locate(t, relative)
 }
@@ -0,0 +1,123 @@
+package std
+
+import (
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/access"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+// A Snapshot is an immutable handle to a Thought's revelation as it was at a single moment, which can be freely read
+// without holding the thought's gate.
+//
+// NOTE: Describing a thought replaces its revelation rather than modifying it, so a snapshot remains intact for as
+// long as nobody mutates a revealed reference type (such as a map or slice) in place.
+type Snapshot[T any] struct {
+	revelation T
+	moment     time.Time
+	revision   uint64
+}
+
+// Reveal returns the revelation held by this Snapshot.
+func (s Snapshot[T]) Reveal() T {
+	return s.revelation
+}
+
+// Moment returns when this Snapshot was taken.
+func (s Snapshot[T]) Moment() time.Time {
+	return s.moment
+}
+
+// Revision returns how many times the Thought had been described when this Snapshot was taken.
+func (s Snapshot[T]) Revision() uint64 {
+	return s.revision
+}
+
+// Snapshot returns an immutable handle to the current revelation of this Thought.
+//
+// NOTE: This follows the same constraints as Reveal.
+func (t *Thought[T]) Snapshot(code ...any) (Snapshot[T], error) {
+	var snap Snapshot[T]
+	err := t.revealWith(nil, func() {
+		snap = Snapshot[T]{
+			revelation: t.revelation,
+			moment:     time.Now(),
+			revision:   t.revision,
+		}
+	}, code...)
+	return snap, err
+}
+
+// Remember begins keeping a history of this Thought's revisions, bounded by the provided limit and observance window.
+// A limit of 0 is unbounded, and if no window is provided, atlas.ObservanceWindow is used.
+//
+// NOTE: The current revelation is remembered as of this moment, and calling this again discards any prior history.
+func (t *Thought[T]) Remember(limit uint, window ...*time.Duration) {
+	t.sanityCheck()
+	t.gate.Lock()
+	defer t.gate.Unlock()
+
+	t.history = NewTemporalBuffer[T](window...)
+	t.history.Limit = limit
+	t.history.Record(time.Now(), t.revelation)
+}
+
+// AsOf returns the revelation of this Thought as it was at the provided moment.
+//
+// NOTE: This follows the same constraints as Reveal, and returns errs.Forgotten if the thought isn't keeping a history
+// or the moment precedes what it remembers - see Remember.
+func (t *Thought[T]) AsOf(moment time.Time, code ...any) (T, error) {
+	var revelation T
+	forgotten := true
+	err := t.revealWith(nil, func() {
+		if t.history == nil {
+			return
+		}
+		t.history.access(func() {
+			if i := t.history.buffer.after(moment); i > 0 {
+				revelation = t.history.buffer.at(i - 1).Element
+				forgotten = false
+			}
+		})
+	}, code...)
+	if err == nil && forgotten {
+		err = errs.Forgotten
+	}
+	return revelation, err
+}
+
+// Revisions returns every remembered revision of this Thought, in temporal order.
+//
+// NOTE: This follows the same constraints as Reveal, and returns errs.Forgotten if the thought isn't keeping a history.
+func (t *Thought[T]) Revisions(code ...any) ([]instant[T], error) {
+	var revisions []instant[T]
+	err := t.revealWith(nil, func() {
+		if t.history != nil {
+			revisions = t.history.Yield()
+		}
+	}, code...)
+	if err == nil && revisions == nil {
+		err = errs.Forgotten
+	}
+	return revisions, err
+}
+
+// revealWith checks the code against the thought's constraint for reading, calls the provided function while
+// holding the gate if granted, and audits the attempt on behalf of the caller.
+func (t *Thought[T]) revealWith(caller *Impulse, fn func(), code ...any) error {
+	t.sanityCheck()
+
+	t.gate.Lock()
+	granted := t.disclosure.Constraint != relationally.Exclusive || t.disclosure.Check(code...)
+	if granted {
+		fn()
+	}
+	t.gate.Unlock()
+
+	t.disclosure.audit(access.Reveal, granted, caller, code...)
+	if !granted {
+		return errs.InvalidCode
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package std
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+// describedAt describes the thought with each value in turn, returning the moment just after each description.
+func describedAt(thought *Thought[int], values ...int) []time.Time {
+	moments := make([]time.Time, len(values))
+	for i, value := range values {
+		time.Sleep(time.Millisecond)
+		thought.Describe(value)
+		moments[i] = time.Now()
+	}
+	return moments
+}
+
+func TestThoughtAsOf(t *testing.T) {
+	thought, _ := NewThought(1)
+	if _, err := thought.AsOf(time.Now()); !errors.Is(err, errs.Forgotten) {
+		t.Fatalf("recalled a thought without a history and got %v, want errs.Forgotten", err)
+	}
+
+	thought.Remember(0)
+	remembered := time.Now()
+	moments := describedAt(thought, 2, 3)
+
+	tests := []struct {
+		name   string
+		moment time.Time
+		want   int
+		err    error
+	}{
+		{"before remembering", remembered.Add(-time.Hour), 0, errs.Forgotten},
+		{"as remembered", remembered, 1, nil},
+		{"after the first revision", moments[0], 2, nil},
+		{"after the last revision", moments[1], 3, nil},
+		{"in the future", time.Now().Add(time.Hour), 3, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := thought.AsOf(test.moment)
+			if !errors.Is(err, test.err) || got != test.want {
+				t.Errorf("recalled %d (%v), want %d (%v)", got, err, test.want, test.err)
+			}
+		})
+	}
+}
+
+func TestThoughtAsOfLimit(t *testing.T) {
+	thought, _ := NewThought(1)
+	thought.Remember(2)
+	remembered := time.Now()
+	moments := describedAt(thought, 2, 3)
+
+	// Only the latest two revisions are kept, so the original revelation has been forgotten
+	if _, err := thought.AsOf(remembered); !errors.Is(err, errs.Forgotten) {
+		t.Errorf("recalled an evicted revision and got %v, want errs.Forgotten", err)
+	}
+	if got, err := thought.AsOf(moments[0]); err != nil || got != 2 {
+		t.Errorf("recalled %d (%v), want 2", got, err)
+	}
+
+	// Remembering again discards everything before it
+	thought.Remember(0)
+	if _, err := thought.AsOf(moments[1]); !errors.Is(err, errs.Forgotten) {
+		t.Errorf("recalled a discarded revision and got %v, want errs.Forgotten", err)
+	}
+	if revisions, _ := thought.Revisions(); len(revisions) != 1 || revisions[0].Element != 3 {
+		t.Errorf("revisions %v after remembering again, want only the current 3", revisions)
+	}
+}
+
+func TestThoughtRevisions(t *testing.T) {
+	thought, _ := NewThought(1)
+	if _, err := thought.Revisions(); !errors.Is(err, errs.Forgotten) {
+		t.Fatalf("listed revisions without a history and got %v, want errs.Forgotten", err)
+	}
+
+	thought.Remember(0)
+	snapshot, _ := thought.Snapshot()
+	describedAt(thought, 2, 3)
+
+	revisions, err := thought.Revisions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 3 {
+		t.Fatalf("listed %d revisions, want 3", len(revisions))
+	}
+	for i, revision := range revisions {
+		if revision.Element != i+1 {
+			t.Errorf("revision %d = %d, want %d", i, revision.Element, i+1)
+		}
+		if i > 0 && revision.Moment.Before(revisions[i-1].Moment) {
+			t.Errorf("revision %d precedes the one before it", i)
+		}
+	}
+	if snapshot.Reveal() != 1 || snapshot.Revision() != 0 {
+		t.Errorf("snapshot holds %d at revision %d, want 1 at revision 0", snapshot.Reveal(), snapshot.Revision())
+	}
+}
+
+func TestThoughtHistoryExclusive(t *testing.T) {
+	d := &Disclosure{Constraint: relationally.Exclusive}
+	d.Code("secret")
+	thought, _ := NewThought(1, d)
+	thought.Remember(0)
+
+	if _, err := thought.AsOf(time.Now(), "wrong"); !errors.Is(err, errs.InvalidCode) {
+		t.Errorf("recalled with the wrong code and got %v, want errs.InvalidCode", err)
+	}
+	if _, err := thought.Revisions("wrong"); !errors.Is(err, errs.InvalidCode) {
+		t.Errorf("listed revisions with the wrong code and got %v, want errs.InvalidCode", err)
+	}
+	if _, err := thought.Snapshot("wrong"); !errors.Is(err, errs.InvalidCode) {
+		t.Errorf("took a snapshot with the wrong code and got %v, want errs.InvalidCode", err)
+	}
+	if got, err := thought.AsOf(time.Now(), "secret"); err != nil || got != 1 {
+		t.Errorf("recalled %d (%v), want 1", got, err)
+	}
+}
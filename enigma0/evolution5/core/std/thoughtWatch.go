@@ -0,0 +1,95 @@
+package std
+
+import (
+	"context"
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/access"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+// A Change describes a single successful Describe of a Thought, as delivered to its watchers.
+type Change[T any] struct {
+	// Old holds the revelation before it was described.
+	Old T
+
+	// New holds the revelation after it was described.
+	New T
+}
+
+// A thoughtWatcher is a single channel a Thought fans its changes out to, alongside the code it was watched with.
+type thoughtWatcher[T any] struct {
+	subscription *temporalSubscription[Change[T]]
+	code         []any
+}
+
+// Watch returns a channel which receives every Change to the Thought, at the moment it was described.  The channel is
+// closed once the provided context is done.  Changes are delivered with backpressure.DropOldest, so a slow watcher
+// only ever misses the oldest changes.
+//
+// NOTE: If the thought is relationally.Exclusive, the code must pass the Disclosure's Check - otherwise, this returns
+// errs.InvalidCode.  The code is checked again before every delivery, so a revoked (or rotated out) code stops receiving.
+func (t *Thought[T]) Watch(ctx context.Context, code ...any) (<-chan instant[Change[T]], error) {
+	return t.WatchAs(ctx, nil, code...)
+}
+
+// WatchAs performs a Watch on behalf of the provided impulse, identifying it by its Bridge to the Disclosure's Audit.
+func (t *Thought[T]) WatchAs(ctx context.Context, caller *Impulse, code ...any) (<-chan instant[Change[T]], error) {
+	t.sanityCheck()
+
+	t.gate.Lock()
+	granted := t.disclosure.Constraint != relationally.Exclusive || t.disclosure.Check(code...)
+	var watcher *thoughtWatcher[T]
+	if granted {
+		watcher = &thoughtWatcher[T]{
+			subscription: newTemporalSubscription[Change[T]](ctx),
+			code:         code,
+		}
+		t.watchers = append(t.watchers, watcher)
+		go t.unwatch(watcher)
+	}
+	t.gate.Unlock()
+
+	t.disclosure.audit(access.Watch, granted, caller, code...)
+	if !granted {
+		return nil, errs.InvalidCode
+	}
+	return watcher.subscription.channel, nil
+}
+
+// unwatch waits for the watcher's context to finish before removing it from the thought and closing its channel.
+func (t *Thought[T]) unwatch(watcher *thoughtWatcher[T]) {
+	sub := watcher.subscription
+	<-sub.ctx.Done()
+
+	t.gate.Lock()
+	for i, w := range t.watchers {
+		if w == watcher {
+			t.watchers = append(t.watchers[:i:i], t.watchers[i+1:]...)
+			break
+		}
+	}
+	t.gate.Unlock()
+
+	sub.gate.Lock()
+	defer sub.gate.Unlock()
+	sub.closed = true
+	close(sub.channel)
+}
+
+// notify delivers the provided change to every watcher whose code still passes the Disclosure's constraint.
+//
+// NOTE: This must be called -without- holding the thought's gate.
+func (t *Thought[T]) notify(watchers []*thoughtWatcher[T], moment time.Time, change Change[T]) {
+	inst := instant[Change[T]]{
+		Moment:  moment,
+		Element: change,
+	}
+	for _, watcher := range watchers {
+		if t.disclosure.Constraint == relationally.Exclusive && !t.disclosure.Check(watcher.code...) {
+			continue
+		}
+		watcher.subscription.deliver(inst)
+	}
+}
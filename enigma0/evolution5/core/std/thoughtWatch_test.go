@@ -0,0 +1,98 @@
+package std
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+func TestThoughtWatch(t *testing.T) {
+	thought, _ := NewThought(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	changes, err := thought.Watch(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	thought.Describe(2)
+	thought.Describe(3)
+	got := received(changes)
+	want := []Change[int]{{Old: 1, New: 2}, {Old: 2, New: 3}}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("received %v, want %v", got, want)
+	}
+
+	// Cancelling the context closes the channel and stops delivery
+	cancel()
+	select {
+	case _, ok := <-changes:
+		if ok {
+			t.Fatal("expected no further changes")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the channel to close")
+	}
+	thought.Describe(4)
+}
+
+func TestThoughtWatchDropsOldest(t *testing.T) {
+	depth := SubscriptionDepth
+	SubscriptionDepth = 2
+	defer func() { SubscriptionDepth = depth }()
+
+	thought, _ := NewThought(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes, _ := thought.Watch(ctx)
+	for i := 1; i <= 5; i++ {
+		thought.Describe(i)
+	}
+	if got := received(changes); len(got) != 2 || got[0].New != 4 || got[1].New != 5 {
+		t.Fatalf("received %v, want only the latest two changes", got)
+	}
+}
+
+func TestThoughtWatchExclusive(t *testing.T) {
+	d := &Disclosure{Constraint: relationally.Exclusive}
+	d.Code("secret")
+	thought, _ := NewThought(1, d)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := thought.Watch(ctx, "wrong"); !errors.Is(err, errs.InvalidCode) {
+		t.Fatalf("watched with the wrong code and got %v, want errs.InvalidCode", err)
+	}
+
+	d.Grant("guest")
+	changes, err := thought.Watch(ctx, "guest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	thought.Describe(2, "secret")
+	if got := received(changes); len(got) != 1 || got[0].New != 2 {
+		t.Fatalf("received %v, want the change to 2", got)
+	}
+
+	// The code is re-checked on every delivery, so a revoked grant stops receiving...
+	d.Revoke("guest")
+	thought.Describe(3, "secret")
+	if got := received(changes); len(got) != 0 {
+		t.Fatalf("received %v after the grant was revoked, want nothing", got)
+	}
+
+	// ...until it's granted again, and an expired grant stops receiving just the same
+	d.Grant("guest", 20*time.Millisecond)
+	thought.Describe(4, "secret")
+	if got := received(changes); len(got) != 1 || got[0] != (Change[int]{Old: 3, New: 4}) {
+		t.Fatalf("received %v after the grant was restored, want the change from 3 to 4", got)
+	}
+	time.Sleep(30 * time.Millisecond)
+	thought.Describe(5, "secret")
+	if got := received(changes); len(got) != 0 {
+		t.Fatalf("received %v after the grant expired, want nothing", got)
+	}
+}
@@ -0,0 +1,69 @@
+package std
+
+import (
+	"errors"
+	"testing"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+func TestThoughtConstraints(t *testing.T) {
+	tests := []struct {
+		constraint relationally.Constrained
+		code       []any
+		reveal     error
+		describe   error
+	}{
+		{constraint: relationally.Open, code: nil, reveal: nil, describe: nil},
+		{constraint: relationally.Open, code: []any{"wrong"}, reveal: nil, describe: nil},
+		{constraint: relationally.Open, code: []any{"secret"}, reveal: nil, describe: nil},
+		{constraint: relationally.Inclusive, code: nil, reveal: nil, describe: errs.InvalidCode},
+		{constraint: relationally.Inclusive, code: []any{"wrong"}, reveal: nil, describe: errs.InvalidCode},
+		{constraint: relationally.Inclusive, code: []any{"secret"}, reveal: nil, describe: nil},
+		{constraint: relationally.Exclusive, code: nil, reveal: errs.InvalidCode, describe: errs.InvalidCode},
+		{constraint: relationally.Exclusive, code: []any{"wrong"}, reveal: errs.InvalidCode, describe: errs.InvalidCode},
+		{constraint: relationally.Exclusive, code: []any{"secret"}, reveal: nil, describe: nil},
+	}
+
+	names := map[relationally.Constrained]string{
+		relationally.Open:      "Open",
+		relationally.Inclusive: "Inclusive",
+		relationally.Exclusive: "Exclusive",
+	}
+	for _, tt := range tests {
+		t.Run(names[tt.constraint]+"/"+codeName(tt.code), func(t *testing.T) {
+			d := &Disclosure{Constraint: tt.constraint}
+			d.Code("secret")
+			thought, _ := NewThought(1, d)
+
+			revealed, err := thought.Reveal(tt.code...)
+			if !errors.Is(err, tt.reveal) || (err != nil) != (tt.reveal != nil) {
+				t.Fatalf("Reveal error = %v, want %v", err, tt.reveal)
+			}
+			if err == nil && revealed != 1 {
+				t.Fatalf("Reveal = %d, want 1", revealed)
+			}
+
+			err = thought.Describe(2, tt.code...)
+			if !errors.Is(err, tt.describe) || (err != nil) != (tt.describe != nil) {
+				t.Fatalf("Describe error = %v, want %v", err, tt.describe)
+			}
+
+			want := 2
+			if tt.describe != nil {
+				want = 1
+			}
+			if revealed, _ = thought.Reveal("secret"); revealed != want {
+				t.Fatalf("revelation = %d after Describe, want %d", revealed, want)
+			}
+		})
+	}
+}
+
+func codeName(code []any) string {
+	if len(code) == 0 {
+		return "none"
+	}
+	return Stringify(code[0])
+}
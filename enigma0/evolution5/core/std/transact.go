@@ -0,0 +1,145 @@
+package std
+
+import (
+	"cmp"
+	"slices"
+	"sync/atomic"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/access"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+// thoughtIDs provides every Thought a unique identifier, which defines the global order their gates are held in.
+var thoughtIDs atomic.Uint64
+
+// A Transactable is anything which can participate in a Transaction - currently, any Thought.
+type Transactable interface {
+	transactionID() uint64
+	transactionGate() *Gate
+
+	// transactionCommit applies the staged revelation and returns anything which must happen after the gates are released.
+	transactionCommit(staged any) func()
+}
+
+// A Transaction stages revelations across several Thoughts so they can be described as a single unit - see Transact.
+//
+// NOTE: A transaction is only valid within the function it was handed to, and must not be shared across goroutines.
+type Transaction struct {
+	participants map[Transactable]bool
+	staged       map[Transactable]any
+	order        []Transactable
+	deferred     []func()
+}
+
+// Transact holds the gates of every provided Thought before calling the provided function, which may stage revelations
+// through DescribeWithin and read them back through RevealWithin.  If the function returns nil, every staged revelation
+// is committed at once - otherwise, they are all discarded and the error is returned.
+//
+// NOTE: The gates are always held in a single global order, so concurrent transactions can never deadlock against
+// one another.  Because the gates are held, calling Reveal or Describe on a participant from within the function
+// will deadlock - please use RevealWithin and DescribeWithin instead.
+//
+// NOTE: Watchers are notified, and every access is audited, only after all the gates are released.
+func Transact(fn func(tx *Transaction) error, thoughts ...Transactable) error {
+	tx := &Transaction{
+		participants: make(map[Transactable]bool, len(thoughts)),
+		staged:       make(map[Transactable]any, len(thoughts)),
+	}
+
+	participants := make([]Transactable, 0, len(thoughts))
+	for _, t := range thoughts {
+		if !tx.participants[t] {
+			tx.participants[t] = true
+			participants = append(participants, t)
+		}
+	}
+	slices.SortFunc(participants, func(a, b Transactable) int {
+		return cmp.Compare(a.transactionID(), b.transactionID())
+	})
+
+	err := func() error {
+		for _, p := range participants {
+			p.transactionGate().Lock()
+		}
+		defer func() {
+			for i := len(participants) - 1; i >= 0; i-- {
+				participants[i].transactionGate().Unlock()
+			}
+		}()
+
+		if err := fn(tx); err != nil {
+			return err
+		}
+		for _, p := range tx.order {
+			tx.deferred = append(tx.deferred, p.transactionCommit(tx.staged[p]))
+		}
+		return nil
+	}()
+
+	for _, deferred := range tx.deferred {
+		deferred()
+	}
+	return err
+}
+
+func (t *Thought[T]) transactionID() uint64 {
+	return t.id
+}
+
+func (t *Thought[T]) transactionGate() *Gate {
+	t.sanityCheck()
+	return t.gate
+}
+
+func (t *Thought[T]) transactionCommit(staged any) func() {
+	return t.revise(staged.(T))
+}
+
+// RevealWithin returns the revelation of this Thought as staged within the provided Transaction - or, if nothing
+// has been staged, its current revelation.
+//
+// NOTE: This follows the same constraints as Reveal, and returns errs.NotTransacted if the thought isn't a participant.
+func (t *Thought[T]) RevealWithin(tx *Transaction, code ...any) (T, error) {
+	t.sanityCheck()
+	var zero T
+	if !tx.participants[t] {
+		return zero, errs.NotTransacted
+	}
+
+	granted := t.disclosure.Constraint != relationally.Exclusive || t.disclosure.Check(code...)
+	tx.deferred = append(tx.deferred, func() {
+		t.disclosure.audit(access.Reveal, granted, nil, code...)
+	})
+	if !granted {
+		return zero, errs.InvalidCode
+	}
+	if staged, ok := tx.staged[t]; ok {
+		return staged.(T), nil
+	}
+	return t.revelation, nil
+}
+
+// DescribeWithin stages the revelation of this Thought within the provided Transaction, to be committed alongside
+// every other participant.
+//
+// NOTE: This follows the same constraints as Describe, and returns errs.NotTransacted if the thought isn't a participant.
+func (t *Thought[T]) DescribeWithin(tx *Transaction, revelation T, code ...any) error {
+	t.sanityCheck()
+	if !tx.participants[t] {
+		return errs.NotTransacted
+	}
+
+	granted := t.disclosure.Constraint == relationally.Open || t.disclosure.Check(code...)
+	tx.deferred = append(tx.deferred, func() {
+		t.disclosure.audit(access.Describe, granted, nil, code...)
+	})
+	if !granted {
+		return errs.InvalidCode
+	}
+	if _, ok := tx.staged[t]; !ok {
+		tx.order = append(tx.order, t)
+	}
+	tx.staged[t] = revelation
+	return nil
+}
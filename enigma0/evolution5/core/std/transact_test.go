@@ -0,0 +1,113 @@
+package std
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/errs"
+	"git.enigmaneering.net/hello-world/enigma0/solution0/evolution5/core/enum/relationally"
+)
+
+func TestTransact(t *testing.T) {
+	count, _ := NewThought(1)
+	name, _ := NewThought("before")
+
+	err := Transact(func(tx *Transaction) error {
+		value, _ := count.RevealWithin(tx)
+		count.DescribeWithin(tx, value+1)
+		if staged, _ := count.RevealWithin(tx); staged != 2 {
+			t.Errorf("revealed %d within the transaction, want the staged 2", staged)
+		}
+		return name.DescribeWithin(tx, "after")
+	}, name, count, count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := count.Reveal(); value != 2 {
+		t.Errorf("committed %d, want 2", value)
+	}
+	if value, _ := name.Reveal(); value != "after" {
+		t.Errorf("committed %q, want \"after\"", value)
+	}
+}
+
+func TestTransactDiscardsOnError(t *testing.T) {
+	count, _ := NewThought(1)
+	failure := errors.New("failure")
+	err := Transact(func(tx *Transaction) error {
+		count.DescribeWithin(tx, 100)
+		return failure
+	}, count)
+	if !errors.Is(err, failure) {
+		t.Fatalf("transacted and got %v, want the function's error", err)
+	}
+	if value, _ := count.Reveal(); value != 1 {
+		t.Errorf("revealed %d after a failed transaction, want the untouched 1", value)
+	}
+
+	outsider, _ := NewThought(0)
+	err = Transact(func(tx *Transaction) error {
+		return outsider.DescribeWithin(tx, 1)
+	}, count)
+	if !errors.Is(err, errs.NotTransacted) {
+		t.Errorf("described a non-participant and got %v, want errs.NotTransacted", err)
+	}
+}
+
+func TestTransactChecksCodes(t *testing.T) {
+	d := &Disclosure{Constraint: relationally.Exclusive}
+	d.Code("secret")
+	guarded, _ := NewThought(1, d)
+
+	err := Transact(func(tx *Transaction) error {
+		if _, err := guarded.RevealWithin(tx, "wrong"); !errors.Is(err, errs.InvalidCode) {
+			t.Errorf("revealed with the wrong code and got %v, want errs.InvalidCode", err)
+		}
+		return guarded.DescribeWithin(tx, 2, "wrong")
+	}, guarded)
+	if !errors.Is(err, errs.InvalidCode) {
+		t.Fatalf("described with the wrong code and got %v, want errs.InvalidCode", err)
+	}
+	if value, _ := guarded.Reveal("secret"); value != 1 {
+		t.Errorf("revealed %d, want the untouched 1", value)
+	}
+}
+
+func TestTransactOrdersGates(t *testing.T) {
+	a, _ := NewThought(0)
+	b, _ := NewThought(0)
+	increment := func(first, second *Thought[int]) {
+		Transact(func(tx *Transaction) error {
+			x, _ := first.RevealWithin(tx)
+			y, _ := second.RevealWithin(tx)
+			first.DescribeWithin(tx, x+1)
+			return second.DescribeWithin(tx, y+1)
+		}, first, second)
+	}
+
+	// Transactions naming their thoughts in opposite orders would deadlock if the gates were held in the order given
+	done := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func() { defer wg.Done(); increment(a, b) }()
+			go func() { defer wg.Done(); increment(b, a) }()
+		}
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected opposing transactions to finish without deadlocking")
+	}
+
+	x, _ := a.Reveal()
+	y, _ := b.Reveal()
+	if x != 200 || y != 200 {
+		t.Errorf("committed %d and %d, want 200 each", x, y)
+	}
+}